@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/restmapper"
+)
+
+// applyResult is the outcome for one document of an applied file.
+type applyResult struct {
+	object string // kind/name, or the document number if it couldn't be decoded
+	action string // "created" or "configured", like kubectl apply
+	err    error
+}
+
+type applyMsg struct {
+	path    string
+	results []applyResult
+}
+
+// fieldManager owns the fields kubeview sets with server-side apply.
+const fieldManager = "kubeview"
+
+// applyFile creates or updates every object in a YAML or JSON file. Files may
+// hold several documents separated by "---". Objects without a namespace go
+// to namespace, or "default" when all namespaces are selected.
+func applyFile(clientset *kubernetes.Clientset, dynamicClient dynamic.Interface, path, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		f, err := os.Open(path)
+		if err != nil {
+			return errMsg{err}
+		}
+		defer f.Close()
+
+		// A broken aggregated API, e.g. metrics-server, fails only its own
+		// group, so the kinds of the others still map
+		groups, err := restmapper.GetAPIGroupResources(clientset.Discovery())
+		if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+			return errMsg{err}
+		}
+		mapper := restmapper.NewDiscoveryRESTMapper(groups)
+		return applyMsg{path: path, results: applyDocuments(mapper, dynamicClient, f, namespace)}
+	}
+}
+
+func applyDocuments(mapper meta.RESTMapper, dynamicClient dynamic.Interface, r io.Reader, namespace string) []applyResult {
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	var results []applyResult
+	decoder := utilyaml.NewYAMLOrJSONDecoder(r, 4096)
+	for doc := 1; ; doc++ {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if !errors.Is(err, io.EOF) {
+				// The decoder can't resync after a syntax error, so stop here.
+				results = append(results, applyResult{object: fmt.Sprintf("document %d", doc), err: err})
+			}
+			return results
+		}
+		if len(obj.Object) == 0 {
+			continue // Empty document, e.g. a trailing "---"
+		}
+		results = append(results, applyObject(mapper, dynamicClient, obj, namespace))
+	}
+}
+
+func applyObject(mapper meta.RESTMapper, dynamicClient dynamic.Interface, obj *unstructured.Unstructured, namespace string) applyResult {
+	res := applyResult{object: fmt.Sprintf("%s/%s", obj.GetKind(), obj.GetName())}
+	gvk := obj.GroupVersionKind()
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if err != nil {
+		res.err = err
+		return res
+	}
+
+	client := dynamicClient.Resource(mapping.Resource)
+	var ri dynamic.ResourceInterface = client
+	if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+		if obj.GetNamespace() == "" {
+			obj.SetNamespace(namespace)
+		}
+		ri = client.Namespace(obj.GetNamespace())
+	}
+
+	ctx, cancel := apiContext() // Per object, so long files don't run out of time
+	defer cancel()
+	// Server-side apply only sets the fields in the manifest, so e.g. the
+	// replicas of an autoscaled Deployment are left alone when it has none.
+	// The Get only tells "created" from "configured".
+	_, err = ri.Get(ctx, obj.GetName(), metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		res.action = "created"
+	case err != nil:
+		res.err = err
+		return res
+	default:
+		res.action = "configured"
+	}
+	_, res.err = ri.Apply(ctx, obj.GetName(), obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: true})
+	return res
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// keyHelp describes one key binding shown in the help view.
+type keyHelp struct {
+	keys string
+	desc string
+}
+
+// keyGroup is a set of bindings that apply in the same context. view is the
+// view the help was opened from and source the list its details came from.
+type keyGroup struct {
+	title   string
+	applies func(view, source viewState) bool
+	keys    []keyHelp
+}
+
+// listViews are the views that show a navigable list of resources.
+var listViews = map[viewState]bool{
+	viewNodes:           true,
+	viewPods:            true,
+	viewPVCs:            true,
+	viewPVs:             true,
+	viewDeployments:     true,
+	viewStatefulSets:    true,
+	viewDaemonSets:      true,
+	viewServices:        true,
+	viewNetworkPolicies: true,
+	viewEvents:          true,
+	viewRoles:           true,
+	viewRoleBindings:    true,
+	viewResourceQuotas:  true,
+	viewLimitRanges:     true,
+	viewPDBs:            true,
+	viewCronJobs:        true,
+	viewEndpointSlices:  true,
+	viewCRDs:            true,
+	viewCustomResources: true,
+	viewNodeMap:         true,
+	viewResourceCounts:  true,
+}
+
+func inView(views ...viewState) func(view, source viewState) bool {
+	return func(view, _ viewState) bool {
+		for _, v := range views {
+			if view == v {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func inDetailsOf(sources ...viewState) func(view, source viewState) bool {
+	return func(view, s viewState) bool {
+		return view == viewDetails && inView(sources...)(s, 0)
+	}
+}
+
+// keymap lists every key binding by context. The help view is generated from
+// it, so new bindings must be added here to show up there; a test checks that
+// each key listed is handled in its views.
+var keymap = []keyGroup{
+	{
+		title:   "Global",
+		applies: func(view, _ viewState) bool { return listViews[view] || view == viewDashboard },
+		keys: []keyHelp{
+			{"q, ctrl+c", "Quit"},
+			{"r", "Open resource selection menu"},
+			{"D", "Show cluster dashboard"},
+			{"ctrl+p", "Search pods, workloads, services and ConfigMaps by name"},
+			{"ctrl+y", "Copy the selected resource's name"},
+			{"U", "Toggle CPU/memory units between millicores/MiB and cores/GiB"},
+			{"w", "Toggle wide columns (age, node, IP, images, labels...), except in Events"},
+			{"A", "Check access (can-i)"},
+			{"a", "Apply a YAML/JSON file"},
+			{"N", "Select namespace"},
+			{"z", "Switch to the selected item's namespace (from all namespaces)"},
+			{"F", "Switch to the next favorite namespace"},
+			{"tab, shift+tab", "Switch cluster (with -contexts)"},
+		},
+	},
+	{
+		title:   "Navigation",
+		applies: func(view, _ viewState) bool { return listViews[view] },
+		keys: []keyHelp{
+			{"up/down, j", "Move cursor"},
+			{"<number> enter", "Jump to row number"},
+			{"enter", "View details"},
+			{"m", "Mark for diff, then press on another row of the same kind to compare"},
+			{"esc", "Go back"},
+		},
+	},
+	{
+		title:   "Menus",
+		applies: inView(viewResourceMenu, viewNamespaces),
+		keys: []keyHelp{
+			{"up/down", "Move cursor"},
+			{"enter", "Select"},
+			{"esc", "Go back"},
+		},
+	},
+	{
+		title:   "Resource Menu",
+		applies: inView(viewResourceMenu),
+		keys: []keyHelp{
+			{"<text>", "Narrow the menu to types containing the text"},
+			{"backspace", "Delete the last typed character, or go back"},
+		},
+	},
+	{
+		title:   "Namespaces",
+		applies: inView(viewNamespaces),
+		keys: []keyHelp{
+			{"a", "Create a namespace"},
+			{"d", "Delete the selected namespace (type its name to confirm)"},
+			{"f", "Toggle favorite (pinned at the top)"},
+			{"ctrl+y", "Copy the selected namespace's name"},
+		},
+	},
+	{
+		title:   "Dashboard",
+		applies: inView(viewDashboard),
+		keys: []keyHelp{
+			{"t", "Cycle top-N size (5/10/15)"},
+			{"s", "Toggle cluster-wide / selected namespace scope"},
+			{"esc", "Go back"},
+		},
+	},
+	{
+		title:   "Events",
+		applies: inView(viewEvents),
+		keys: []keyHelp{
+			{"f", "Follow: stay on the newest event as they arrive"},
+			{"w", "Toggle warnings only"},
+			{"o", "Cycle involved object kind (Pod/Deployment/Node)"},
+			{"g", "Group events by involved object; enter lists an object's events"},
+		},
+	},
+	{
+		title:   "Nodes",
+		applies: inView(viewNodes),
+		keys: []keyHelp{
+			{"p", "Show pods scheduled on the node"},
+		},
+	},
+	{
+		title:   "Services",
+		applies: inView(viewServices),
+		keys: []keyHelp{
+			{"f", "Port-forward a local port to the service (needs kubectl)"},
+		},
+	},
+	{
+		title:   "Node Map",
+		applies: inView(viewNodeMap),
+		keys: []keyHelp{
+			{"enter", "Show the pods of the selected node"},
+		},
+	},
+	{
+		title:   "Pods",
+		applies: inView(viewPods),
+		keys: []keyHelp{
+			{"s", "Toggle sorting by restart count"},
+			{"o", "Go to the pod's top-level controller"},
+			{"n", "Show only pods on a node"},
+			{"C", "Delete all completed, failed and evicted pods in the list"},
+		},
+	},
+	{
+		title:   "Deployments",
+		applies: inView(viewDeployments),
+		keys: []keyHelp{
+			{"+, -", "Add or remove one replica (removing asks first)"},
+		},
+	},
+	{
+		title:   "Workloads",
+		applies: inView(viewDeployments, viewStatefulSets, viewDaemonSets),
+		keys: []keyHelp{
+			{"p", "Show the pods it manages"},
+		},
+	},
+	{
+		title:   "CronJobs",
+		applies: inView(viewCronJobs),
+		keys: []keyHelp{
+			{"s", "Suspend or resume the selected CronJob"},
+		},
+	},
+	{
+		title:   "Custom Resources",
+		applies: inView(viewCRDs),
+		keys: []keyHelp{
+			{"enter", "List resources of the selected CRD"},
+		},
+	},
+	{
+		title:   "Details",
+		applies: inView(viewDetails),
+		keys: []keyHelp{
+			{"up/down", "Scroll"},
+			{"y", "View YAML"},
+			{"[ / ]", "Highlight the previous or next field"},
+			{"ctrl+y", "Copy the highlighted field's value"},
+			{"D", "Run kubectl describe in a pager ($PAGER, or less)"},
+			{"esc", "Go back"},
+		},
+	},
+	{
+		title:   "Pod Details",
+		applies: inDetailsOf(viewPods),
+		keys: []keyHelp{
+			{"l", "View logs"},
+			{"P", "View the previous logs of restarted containers, with why they ended"},
+			{"g", "Grep the last N log lines, e.g. \"500 timeout|refused\""},
+			{"d", "Delete pod"},
+		},
+	},
+	{
+		title:   "Workload Details",
+		applies: inDetailsOf(viewDeployments, viewStatefulSets, viewDaemonSets),
+		keys: []keyHelp{
+			{"l", "View logs from all pods"},
+			{"p", "Show the pods it manages"},
+			{"s", "Watch rollout status (StatefulSet partitions included)"},
+			{"R", "Restart all pods (rollout restart)"},
+			{"d", "Delete it and its pods"},
+		},
+	},
+	{
+		title:   "Deployment Details",
+		applies: inDetailsOf(viewDeployments),
+		keys: []keyHelp{
+			{"r", "Scale replicas"},
+			{"i", "Set container image"},
+			{"u", "Roll back to previous revision"},
+		},
+	},
+	{
+		title:   "Logs",
+		applies: inView(viewLogs),
+		keys: []keyHelp{
+			{"up/down", "Scroll"},
+			{"esc", "Back to details"},
+		},
+	},
+	{
+		title:   "YAML",
+		applies: inView(viewYAML),
+		keys: []keyHelp{
+			{"up/down", "Scroll"},
+			{"#", "Toggle line numbers"},
+			{"j", "Switch between YAML and JSON"},
+			{"esc", "Back to details"},
+		},
+	},
+	{
+		title:   "Diff",
+		applies: inView(viewDiff),
+		keys: []keyHelp{
+			{"up/down", "Scroll"},
+			{"esc", "Go back"},
+		},
+	},
+	{
+		title:   "Rollout Status",
+		applies: inView(viewRolloutStatus),
+		keys: []keyHelp{
+			{"esc", "Back to details"},
+		},
+	},
+	{
+		title:   "Help",
+		applies: func(viewState, viewState) bool { return true },
+		keys: []keyHelp{
+			{"?, esc", "Close this help"},
+		},
+	},
+}
+
+// helpContext returns the view the help was opened from and the list that
+// view's details belong to.
+func (m model) helpContext() (viewState, viewState) {
+	if len(m.viewStack) == 0 {
+		return m.view, m.view
+	}
+	return m.viewStack[len(m.viewStack)-1], m.detailsSource()
+}
+
+func (m *model) renderHelpView() string {
+	var b strings.Builder
+	b.WriteString(m.styles.HeaderText.Render("Keybindings") + "\n\n")
+
+	view, source := m.helpContext()
+	for _, g := range keymap {
+		if !g.applies(view, source) {
+			continue
+		}
+		b.WriteString(fmt.Sprintf("  %s:\n", g.title))
+		for _, k := range g.keys {
+			b.WriteString(fmt.Sprintf("    %s: %s\n", k.keys, k.desc))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
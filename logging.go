@@ -0,0 +1,57 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+)
+
+// logger receives kubeview's own debug logs. It discards everything unless
+// -log-file is given, since the TUI owns the terminal.
+var logger = slog.New(slog.DiscardHandler)
+
+// openLogFile sends debug logs to path as JSON lines. The returned function
+// closes the file.
+func openLogFile(path string) (func() error, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	logger = slog.New(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return f.Close, nil
+}
+
+// loggingTransport logs every API request with its status and duration.
+type loggingTransport struct {
+	next http.RoundTripper
+}
+
+func (t loggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	attrs := []any{
+		slog.String("method", req.Method),
+		slog.String("url", req.URL.RequestURI()),
+		slog.Duration("duration", time.Since(start)),
+	}
+	if err != nil {
+		logger.Error("api request failed", append(attrs, slog.Any("error", err))...)
+		return resp, err
+	}
+	logger.Debug("api request", append(attrs, slog.Int("status", resp.StatusCode))...)
+	return resp, nil
+}
+
+// viewName names a view for the logs, using its state file name when it has one.
+func viewName(v viewState) string {
+	if name, ok := stateViews[v]; ok {
+		return name
+	}
+	return "view " + strconv.Itoa(int(v))
+}
+
+func logViewChange(from, to viewState) {
+	logger.Debug("view changed", slog.String("from", viewName(from)), slog.String("to", viewName(to)))
+}
@@ -1,38 +1,114 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"context"
+	stdjson "encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
+	"log/slog"
+	"math"
+	"net/http"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/atotto/clipboard"
+	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+	"github.com/pmezard/go-difflib/difflib"
+	"golang.org/x/sync/errgroup"
 	appsv1 "k8s.io/api/apps/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/metadata"
 	"k8s.io/client-go/tools/clientcmd"
 	v1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
 	metrics "k8s.io/metrics/pkg/client/clientset/versioned"
+	"sigs.k8s.io/yaml"
 )
 
 var refreshInterval = 5 * time.Second
 
+// pvcUsageInterval is how often PVC usage is reread while the PVCs view is
+// open. Each read fetches the full kubelet stats summary of every node running
+// a PVC-backed pod, and volumes fill slowly, so it trails the list refresh.
+var pvcUsageInterval = time.Minute
+
+// historySize is the number of dashboard samples kept for the usage trend chart.
+const historySize = 60
+
+const (
+	minChartWidth        = 20  // Narrower than this, the axis labels leave no room to plot
+	sideBySideChartWidth = 100 // Below this the CPU and memory charts stack vertically
+)
+
+// topNChoices are the dashboard top-N sizes cycled through with the "t" key.
+var topNChoices = []int{5, 10, 15}
+
+// maxWatchedEvents bounds how many events the live Events view retains.
+const maxWatchedEvents = 500
+
+// eventKindFilters are the involved object kinds cycled through with the "o" key in the Events view.
+var eventKindFilters = []string{"", "Pod", "Deployment", "Node"}
+
+// apiTimeout bounds each API call, so a hung or unreachable API server shows
+// up as an error instead of freezing the view. Set with -timeout.
+var apiTimeout = 10 * time.Second
+
+// errBannerTimeout is how long an error banner stays visible before it is cleared.
+var errBannerTimeout = 5 * time.Second
+
+// rootCtx is the parent of every API call, watch and stream. stopAll cancels
+// it on exit so nothing is left holding a connection to the API server.
+var rootCtx, stopAll = context.WithCancel(context.Background())
+
+// apiContext returns a context for one API call, cancelled after apiTimeout.
+// Watches and followed log streams don't use it, since they are meant to stay open.
+func apiContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(rootCtx, apiTimeout)
+}
+
 type viewState int
 
 const (
@@ -43,1399 +119,7026 @@ const (
 	viewDeployments
 	viewStatefulSets
 	viewDaemonSets
+	viewCronJobs
 	viewServices
 	viewNetworkPolicies
 	viewEvents
+	viewRoles
+	viewRoleBindings
+	viewResourceQuotas
+	viewLimitRanges
+	viewPDBs
+	viewEndpointSlices
 	viewNamespaces
 	viewDetails
 	viewLogs
 	viewScaling
-	viewConfirmDelete
+	viewSetImage
+	viewConfirm
 	viewYAML
 	viewDashboard // New view state for Dashboard
 	viewResourceMenu
 	viewHelp
+	viewRolloutStatus
+	viewCRDs
+	viewCustomResources
+	viewCanI
+	viewCreateNamespace
+	viewDeleteNamespace
+	viewApply
+	viewNodeFilter
+	viewDiff
+	viewSearch
+	viewNodeMap
+	viewResourceCounts
+	viewPortForward
+	viewLogGrep
 )
 
 type model struct {
 	view               viewState
-	previousView       viewState
+	viewStack          []viewState // Views to return to on esc, most recent last
 	nodes              []v1.Node
 	nodeMetrics        map[string]v1beta1.NodeMetrics
+	nodeAllocations    map[string]nodeAllocation // Scheduled pods and requests keyed by node name
+	nodeEvents         []v1.Event                // Events of the node shown in details, kept so refreshes can re-render it
+	daemonSetCoverage  []daemonSetNodeRow        // Nodes of the DaemonSet shown in details, nil until fetched
+	nodeSummary        string                    // Health counts for the Nodes view, computed when nodes arrive
 	pods               []v1.Pod
+	podSummary         string         // Health counts for the Pods view, computed when pods arrive
+	podsByRestarts     bool           // Sort the Pods view by restart count, highest first
+	podFilter          *podFilter     // Scopes the Pods view to one workload's pods
+	rowTarget          *controllerMsg // Row to select once its list is loaded
+	searchItems        []resourceRef  // Names searchable with ctrl+p, loaded when the search opens
+	searchCursor       int            // Selected search result
+	diffMark           *resourceRef   // Resource marked as the left side of a YAML diff
+	diffTitle          string         // What viewDiff compares
+	restartThreshold   int32          // Restart counts above this are highlighted
+	wholeUnits         bool           // Show CPU in cores and memory in GiB instead of millicores and MiB
+	wideLists          bool           // Lists add columns like kubectl get -o wide, toggled with w
 	podMetrics         map[string]v1beta1.PodMetrics
+	nodeMap            []nodeMapRow       // Rows of the Node Map view, nodes each followed by their pods
+	resourceCounts     []resourceCountRow // Rows of the Resource Counts view, the cluster total first
 	pvcs               []v1.PersistentVolumeClaim
+	pvcUsage           map[string]volumeStats // Filesystem usage by namespace/name, from the kubelets
+	pvcUsageStamp      fetchStamp             // When pvcUsage was last requested, and for which namespace
 	pvs                []v1.PersistentVolume
 	deployments        []appsv1.Deployment
+	deploymentSummary  string // Availability counts for the Deployments view
 	statefulsets       []appsv1.StatefulSet
 	daemonsets         []appsv1.DaemonSet
 	services           []v1.Service
 	netpols            []networkingv1.NetworkPolicy
 	events             []v1.Event
+	eventWatch         watch.Interface            // Live watch backing the Events view, nil when not watching
+	eventWarningsOnly  bool                       // Only show Warning events
+	eventKindFilter    string                     // Only show events for this involved object kind, "" for all
+	eventGrouped       bool                       // Collapse events for the same involved object into one row
+	eventFollow        bool                       // Keep the newest event selected as the watch delivers new ones
+	eventsUpdated      time.Time                  // When the Events list or its watch last delivered data
+	copied             string                     // Text last copied with ctrl+y, shown in the footer until the next key
+	copiedViaTerminal  bool                       // The copy went through OSC 52, which the terminal may ignore
+	detailsField       int                        // Details field ctrl+y copies, moved with [ and ]
+	terminalSeq        string                     // Escape sequences (bell, OSC 52 copy) written with the next frames
+	notifyRollouts     bool                       // Watch rollouts started from kubeview and ring the bell when they end
+	watchedRollouts    map[string]bool            // "namespace/name" of deployments whose rollout is being polled
+	rolloutNotice      string                     // Outcome of a watched rollout, shown in the footer until the next key
+	rolloutFailed      bool                       // rolloutNotice reports a failure
+	forbidden          map[viewState]forbiddenMsg // Views whose list was refused by RBAC
+	roles              []rbacv1.Role
+	roleBindings       []rbacv1.RoleBinding
+	resourceQuotas     []v1.ResourceQuota
+	limitRanges        []v1.LimitRange
+	pdbs               []policyv1.PodDisruptionBudget
+	cronJobs           []batchv1.CronJob
+	endpointSlices     []discoveryv1.EndpointSlice
 	namespaces         []v1.Namespace
+	favoriteNamespaces []string // Bookmarked namespaces, pinned at the top of the namespace list
+	excludedNamespaces []string // Hidden from all-namespaces lists, from -exclude-ns
+	crds               []crdInfo
+	selectedCRD        crdInfo // CRD whose resources are listed in viewCustomResources
+	customResources    []unstructured.Unstructured
 	resourceTypes      []string
+	menuFilter         string // Typed text narrowing the resource menu to matching types
 	selectedNamespace  string // "" == all
 	details            string
-	yamlContent        string    // New field for YAML content
-	clusterCPUUsage    string    // Aggregated cluster CPU usage
-	clusterMemoryUsage string    // Aggregated cluster Memory usage
-	topPodsByCPU       []v1.Pod  // Top pods by CPU usage
-	topPodsByMemory    []v1.Pod  // Top pods by Memory usage
-	topNodesByCPU      []v1.Node // Top nodes by CPU usage
-	topNodesByMemory   []v1.Node // Top nodes by Memory usage
+	logsSource         string                          // Pod or deployment shown in viewLogs
+	yamlContent        string                          // New field for YAML content
+	yamlLineNumbers    bool                            // Prefix YAML lines with line numbers in the viewport only
+	yamlAsJSON         bool                            // Show the YAML view's object as indented JSON instead
+	yamlObject         runtime.Object                  // Object shown in viewYAML
+	clusterTotals      clusterTotals                   // Aggregated cluster usage and pod requests against capacity
+	cpuHistory         []timeserieslinechart.TimePoint // Rolling cluster CPU% samples
+	memoryHistory      []timeserieslinechart.TimePoint // Rolling cluster Memory% samples
+	topPodsByCPU       []v1.Pod                        // Top pods by CPU usage
+	topPodsByMemory    []v1.Pod                        // Top pods by Memory usage
+	topNodesByCPU      []v1.Node                       // Top nodes by CPU usage
+	topNodesByMemory   []v1.Node                       // Top nodes by Memory usage
+	topN               int                             // Number of top pods/nodes shown on the dashboard
+	dashboardScoped    bool                            // Scope the dashboard to selectedNamespace
+	clusterHealth      *clusterHealthMsg               // Version and control-plane health atop the dashboard, nil until fetched
 	cursor             int
+	listOffset         int                      // Index of the first visible list row, keeps the cursor on screen
+	fetched            map[viewState]fetchStamp // When each list view's data was last fetched
+	jumpBuffer         string                   // Row number typed so far for number-jump navigation
 	err                error
+	errSeq             int // Incremented per error so stale clear timers are ignored
+	tickSeq            int // Incremented per armed refresh timer so superseded ones are ignored
 	clientset          *kubernetes.Clientset
+	dynamicClient      dynamic.Interface
+	metadataClient     metadata.Interface
 	metricsClientset   *metrics.Clientset
+	kubeconfig         string // Passed on to kubectl, with kubeContext, so it targets the same cluster
+	kubeContext        string // Context the clients were built for, "" for the kubeconfig's current one
 	styles             Styles
 	viewport           viewport.Model
+	viewportContent    string // Unwrapped viewport content, re-wrapped on resize
 	textInput          textinput.Model
+	progress           progress.Model
+	rolloutDeployment  *appsv1.Deployment // Latest fetch of the workload in viewRolloutStatus; only its kind is set
+	rolloutStatefulSet *appsv1.StatefulSet
+	rolloutDaemonSet   *appsv1.DaemonSet
+	rolloutWorkload    workload  // Workload viewRolloutStatus shows, refetched on every tick
+	canIResult         *canIMsg  // Last access review answered in viewCanI
+	lastApply          *applyMsg // Outcome of the last file applied in viewApply
+	confirmPrompt      string    // Question shown in viewConfirm
+	confirmAction      tea.Cmd   // Command run when the confirmation is accepted
+	deletingNamespace  string    // Namespace whose name must be typed in viewDeleteNamespace
 	ready              bool
 }
 
-type tickMsg time.Time
-type logsMsg struct{ logs string }
-type scaleMsg struct{}
+// tickMsg refreshes the current view. seq names the timer that sent it, or
+// is zero for an immediate refresh.
+type tickMsg struct{ seq int }
+type logsMsg struct {
+	logs   string
+	source string // Pod or deployment the logs came from, shown in the header
+}
+type scaleMsg struct{ namespace, name string }
+type scaleDownPreviewMsg struct {
+	namespace, name string
+	from, to        int32
+	victims         []string  // Pods likely to be terminated
+	view            viewState // View the scale was asked from
+}
+
+// controllerMsg names the top-level controller of a pod.
+type controllerMsg struct {
+	kind      string
+	namespace string
+	name      string
+}
+type imageSetMsg struct{}
+type searchIndexMsg struct {
+	items []resourceRef
+	err   error // Set when no kind could be listed
+}
+
+// copiedMsg reports that text was copied, through the system clipboard or,
+// when there is none (e.g. over SSH), by asking the terminal with OSC 52.
+type copiedMsg struct {
+	text        string
+	viaTerminal bool
+}
+
+// terminalSeqMsg is an escape sequence for the terminal, e.g. the bell. Only
+// the renderer may write to the terminal while the program runs, so it goes
+// out as part of the next frame.
+type terminalSeqMsg string
+type clearTerminalSeqMsg struct{}
+type nodeMapMsg struct{ rows []nodeMapRow }
+
+// execProcessMsg asks for cmd to run in the terminal while the UI is
+// suspended, then for onExit's message. It's a message rather than a
+// tea.ExecProcess command so tabs can start it themselves and route onExit's
+// message back to the right tab.
+type execProcessMsg struct {
+	cmd    *exec.Cmd
+	onExit func(error) tea.Msg
+}
+type resourceCountsMsg struct{ rows []resourceCountRow }
+type rolloutStatusMsg struct {
+	deployment  *appsv1.Deployment
+	statefulSet *appsv1.StatefulSet
+	daemonSet   *appsv1.DaemonSet
+}
+type rollbackMsg struct{}
+type nodeEventsMsg struct {
+	node   string
+	events []v1.Event
+}
+type daemonSetCoverageMsg struct {
+	namespace, name string
+	rows            []daemonSetNodeRow
+}
+type canIMsg struct {
+	query   string
+	allowed bool
+	reason  string
+}
 type podDeletedMsg struct{}
+type podsDeletedMsg struct{ deleted int }
+type workloadRestartedMsg struct{ workload workload }
+type rolloutPolledMsg struct {
+	namespace, name string
+	deployment      *appsv1.Deployment
+	err             error
+}
+type workloadDeletedMsg struct{ workload workload }
+type namespaceCreatedMsg struct{ name string }
+type namespaceDeletedMsg struct{ name string }
 type nodesMsg struct {
-	nodes   []v1.Node
-	metrics map[string]v1beta1.NodeMetrics
+	nodes       []v1.Node
+	metrics     map[string]v1beta1.NodeMetrics
+	allocations map[string]nodeAllocation
+}
+
+// nodeAllocation summarizes the non-terminated pods scheduled on a node.
+type nodeAllocation struct {
+	pods           int
+	cpuRequests    resource.Quantity
+	memoryRequests resource.Quantity
+}
+
+// podFilter limits the Pods view to the pods selected by a workload or
+// scheduled on a node.
+type podFilter struct {
+	owner     string // e.g. "deployment/web" or "node/worker-1", for the title
+	namespace string // Empty for all namespaces
+	selector  string // Label selector
+	field     string // Field selector, evaluated by the API server
 }
+
 type podsMsg struct {
-	pods    []v1.Pod
-	metrics map[string]v1beta1.PodMetrics
+	pods      []v1.Pod
+	metrics   map[string]v1beta1.PodMetrics
+	namespace string
+}
+type pvcsMsg struct {
+	pvcs      []v1.PersistentVolumeClaim
+	namespace string
+}
+type pvcUsageMsg struct {
+	usage map[string]volumeStats // By namespace/name, for PVCs mounted by a running pod
 }
-type pvcsMsg struct{ pvcs []v1.PersistentVolumeClaim }
 type pvsMsg struct{ pvs []v1.PersistentVolume }
-type deploymentsMsg struct{ deployments []appsv1.Deployment }
-type statefulsetsMsg struct{ statefulsets []appsv1.StatefulSet }
-type daemonsetsMsg struct{ daemonsets []appsv1.DaemonSet }
-type servicesMsg struct{ services []v1.Service }
-type networkPoliciesMsg struct{ policies []networkingv1.NetworkPolicy }
-type eventsMsg struct{ events []v1.Event }
+type deploymentsMsg struct {
+	deployments []appsv1.Deployment
+	namespace   string
+}
+type statefulsetsMsg struct {
+	statefulsets []appsv1.StatefulSet
+	namespace    string
+}
+type daemonsetsMsg struct {
+	daemonsets []appsv1.DaemonSet
+	namespace  string
+}
+type servicesMsg struct {
+	services  []v1.Service
+	namespace string
+}
+type networkPoliciesMsg struct {
+	policies  []networkingv1.NetworkPolicy
+	namespace string
+}
+type eventsMsg struct {
+	events          []v1.Event
+	resourceVersion string
+}
+type eventWatchStartedMsg struct{ watcher watch.Interface }
+type eventWatchMsg struct {
+	watcher watch.Interface
+	event   watch.Event
+}
+type eventWatchClosedMsg struct{ watcher watch.Interface }
+type rolesMsg struct {
+	roles     []rbacv1.Role
+	namespace string
+}
+type roleBindingsMsg struct {
+	bindings  []rbacv1.RoleBinding
+	namespace string
+}
+type resourceQuotasMsg struct {
+	quotas    []v1.ResourceQuota
+	namespace string
+}
+type limitRangesMsg struct {
+	limitRanges []v1.LimitRange
+	namespace   string
+}
+type pdbsMsg struct {
+	pdbs      []policyv1.PodDisruptionBudget
+	namespace string
+}
+type endpointSlicesMsg struct {
+	slices    []discoveryv1.EndpointSlice
+	namespace string
+}
+type cronJobsMsg struct {
+	cronJobs  []batchv1.CronJob
+	namespace string
+}
+type cronJobSuspendedMsg struct{}
 type namespacesMsg struct{ namespaces []v1.Namespace }
+type crdsMsg struct{ crds []crdInfo }
+type customResourcesMsg struct {
+	resources []unstructured.Unstructured
+	namespace string
+}
 type errMsg struct{ err error }
-type yamlMsg struct{ yaml string } // New message type
+type clearErrMsg struct{ seq int }
+type diffMsg struct {
+	title string
+	diff  string
+}
+
+type yamlMsg struct {
+	yaml string
+	obj  runtime.Object // Kept so the YAML view can re-encode it as JSON
+}
+
+// clusterTotals sums usage, pod requests and capacity across the dashboard's nodes.
+type clusterTotals struct {
+	cpuUsage, cpuRequests, cpuCapacity          resource.Quantity
+	memoryUsage, memoryRequests, memoryCapacity resource.Quantity
+}
+
 type dashboardMsg struct {
-	clusterCPUUsage    string
-	clusterMemoryUsage string
-	topPodsByCPU       []v1.Pod
-	topPodsByMemory    []v1.Pod
-	topNodesByCPU      []v1.Node
-	topNodesByMemory   []v1.Node
+	totals           clusterTotals
+	cpuPercent       float64
+	memoryPercent    float64
+	topPodsByCPU     []v1.Pod
+	topPodsByMemory  []v1.Pod
+	topNodesByCPU    []v1.Node
+	topNodesByMemory []v1.Node
+}
+
+// clusterHealthMsg carries the server version and control-plane health shown
+// at the top of the dashboard.
+type clusterHealthMsg struct {
+	version    string
+	components []controlPlaneComponent
+	source     string // Where components came from, "" when the control plane isn't visible
+}
+
+type controlPlaneComponent struct {
+	name    string
+	healthy bool
+	detail  string
 }
 
 func (e errMsg) Error() string { return e.err.Error() }
 
-func doTick() tea.Cmd {
-	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+// refreshError marks an error from fetching a view's rows, which the view's
+// next refresh retries. Errors of one-shot actions aren't marked.
+type refreshError struct{ error }
+
+func (e refreshError) Unwrap() error { return e.error }
+
+// visibleEvents returns the events that pass the Events view's type and kind filters.
+func (m model) visibleEvents() []v1.Event {
+	excluding := m.selectedNamespace == "" && len(m.excludedNamespaces) > 0
+	if !m.eventWarningsOnly && m.eventKindFilter == "" && !excluding {
+		return m.events
+	}
+	var events []v1.Event
+	for _, e := range m.events {
+		if excluding && slices.Contains(m.excludedNamespaces, e.Namespace) {
+			continue // Watched events aren't filtered on arrival
+		}
+		if m.eventWarningsOnly && e.Type != v1.EventTypeWarning {
+			continue
+		}
+		if m.eventKindFilter != "" && e.InvolvedObject.Kind != m.eventKindFilter {
+			continue
+		}
+		events = append(events, e)
+	}
+	return events
 }
 
-func deletePod(clientset *kubernetes.Clientset, namespace, name string) tea.Cmd {
-	return func() tea.Msg {
-		err := clientset.CoreV1().Pods(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
-		if err != nil {
-			return errMsg{err}
+// eventGroup is the events for one involved object, newest first.
+type eventGroup struct {
+	object v1.ObjectReference
+	events []v1.Event
+}
+
+// count sums the group's event counts; an event seen once may leave Count at 0.
+func (g eventGroup) count() int32 {
+	var n int32
+	for _, e := range g.events {
+		n += max(e.Count, 1)
+	}
+	return n
+}
+
+// hasWarning reports whether any of the group's events is a warning.
+func (g eventGroup) hasWarning() bool {
+	for _, e := range g.events {
+		if e.Type == v1.EventTypeWarning {
+			return true
 		}
-		return podDeletedMsg{}
 	}
+	return false
 }
 
-func scaleDeployment(clientset *kubernetes.Clientset, namespace, name string, replicas int32) tea.Cmd {
-	return func() tea.Msg {
-		deployment, err := clientset.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		if err != nil {
-			return errMsg{err}
+// groupEvents collapses events by involved object, keeping the order in which
+// each object first appears, so groups stay sorted by their latest event.
+func groupEvents(events []v1.Event) []eventGroup {
+	var groups []eventGroup
+	index := map[v1.ObjectReference]int{}
+	for _, e := range events {
+		key := v1.ObjectReference{Kind: e.InvolvedObject.Kind, Namespace: e.InvolvedObject.Namespace, Name: e.InvolvedObject.Name}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, eventGroup{object: key})
 		}
+		groups[i].events = append(groups[i].events, e)
+	}
+	return groups
+}
 
-		deployment.Spec.Replicas = &replicas
-		_, err = clientset.AppsV1().Deployments(namespace).Update(context.Background(), deployment, metav1.UpdateOptions{})
-		if err != nil {
-			return errMsg{err}
+// selectedEvent returns the event under the cursor, or the latest event of
+// the selected object when events are grouped.
+func (m model) selectedEvent() v1.Event {
+	if m.eventGrouped {
+		return groupEvents(m.visibleEvents())[m.cursor].events[0]
+	}
+	return m.visibleEvents()[m.cursor]
+}
+
+// watchingEvents reports whether the Events view, or a view opened from it, is showing.
+func (m model) watchingEvents() bool {
+	return m.view == viewEvents || (m.detailsSource() == viewEvents && (m.view == viewDetails || m.view == viewYAML))
+}
+
+// stopEventWatch stops the live Events watch, if any.
+func (m *model) stopEventWatch() {
+	if m.eventWatch != nil {
+		m.eventWatch.Stop()
+		m.eventWatch = nil
+	}
+}
+
+// shutdown stops the model's watches and cancels everything derived from
+// rootCtx, before the program quits.
+func (m *model) shutdown() {
+	m.stopEventWatch()
+	stopAll()
+	logger.Debug("shutting down")
+}
+
+func (m model) isFavoriteNamespace(name string) bool {
+	for _, f := range m.favoriteNamespaces {
+		if f == name {
+			return true
 		}
-		return scaleMsg{}
 	}
+	return false
 }
 
-func getLogs(clientset *kubernetes.Clientset, namespace, podName string) tea.Cmd {
-	return func() tea.Msg {
-		podLogOpts := v1.PodLogOptions{}
-		req := clientset.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
-		podLogs, err := req.Stream(context.Background())
-		if err != nil {
-			return errMsg{err}
+// toggleFavoriteNamespace bookmarks or un-bookmarks a namespace and re-pins the list.
+func (m *model) toggleFavoriteNamespace(name string) {
+	for i, f := range m.favoriteNamespaces {
+		if f == name {
+			m.favoriteNamespaces = append(m.favoriteNamespaces[:i:i], m.favoriteNamespaces[i+1:]...)
+			m.pinFavoriteNamespaces()
+			return
 		}
-		defer podLogs.Close()
+	}
+	m.favoriteNamespaces = append(m.favoriteNamespaces, name)
+	m.pinFavoriteNamespaces()
+}
 
-		var buf bytes.Buffer
-		_, err = io.Copy(&buf, podLogs)
-		if err != nil {
-			return errMsg{err}
+// pinFavoriteNamespaces moves bookmarked namespaces to the top of the list,
+// keeping each group sorted by name.
+func (m *model) pinFavoriteNamespaces() {
+	sort.Slice(m.namespaces, func(i, j int) bool {
+		fi, fj := m.isFavoriteNamespace(m.namespaces[i].Name), m.isFavoriteNamespace(m.namespaces[j].Name)
+		if fi != fj {
+			return fi
+		}
+		return m.namespaces[i].Name < m.namespaces[j].Name
+	})
+}
+
+// nextFavorite returns the favorite after current, wrapping around. If current
+// isn't a favorite the first one is returned.
+func nextFavorite(favorites []string, current string) string {
+	for i, f := range favorites {
+		if f == current {
+			return favorites[(i+1)%len(favorites)]
 		}
-		return logsMsg{logs: buf.String()}
 	}
+	return favorites[0]
 }
 
-func getNodes(clientset *kubernetes.Clientset, metricsClientset *metrics.Clientset) tea.Cmd {
-	return func() tea.Msg {
-		nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
-		if err != nil {
-			return errMsg{err}
+// dashboardNamespace returns the namespace the dashboard is scoped to, or "" for cluster-wide.
+func (m model) dashboardNamespace() string {
+	if m.dashboardScoped {
+		return m.selectedNamespace
+	}
+	return ""
+}
+
+// fetchStamp records when a list view's data was fetched, and for which namespace.
+type fetchStamp struct {
+	namespace string
+	at        time.Time
+}
+
+// clusterScopedViews list resources that don't depend on the selected namespace.
+var clusterScopedViews = map[viewState]bool{viewNodes: true, viewPVs: true, viewCRDs: true, viewNodeMap: true, viewResourceCounts: true}
+
+// fetchedView returns the list view whose data msg carries. Events aren't
+// cached: entering the view always relists so the live watch can start.
+func fetchedView(msg tea.Msg) (viewState, bool) {
+	switch msg.(type) {
+	case nodesMsg:
+		return viewNodes, true
+	case podsMsg:
+		return viewPods, true
+	case pvcsMsg:
+		return viewPVCs, true
+	case pvsMsg:
+		return viewPVs, true
+	case deploymentsMsg:
+		return viewDeployments, true
+	case statefulsetsMsg:
+		return viewStatefulSets, true
+	case daemonsetsMsg:
+		return viewDaemonSets, true
+	case servicesMsg:
+		return viewServices, true
+	case networkPoliciesMsg:
+		return viewNetworkPolicies, true
+	case rolesMsg:
+		return viewRoles, true
+	case roleBindingsMsg:
+		return viewRoleBindings, true
+	case resourceQuotasMsg:
+		return viewResourceQuotas, true
+	case limitRangesMsg:
+		return viewLimitRanges, true
+	case pdbsMsg:
+		return viewPDBs, true
+	case cronJobsMsg:
+		return viewCronJobs, true
+	case endpointSlicesMsg:
+		return viewEndpointSlices, true
+	case crdsMsg:
+		return viewCRDs, true
+	case nodeMapMsg:
+		return viewNodeMap, true
+	case resourceCountsMsg:
+		return viewResourceCounts, true
+	}
+	return 0, false
+}
+
+// fetchedNamespace returns the namespace a list message was fetched from, ""
+// for all namespaces. A reply can land after the user switched namespace, so
+// it, not the selected namespace, says what the message holds.
+func fetchedNamespace(msg tea.Msg) (string, bool) {
+	switch msg := msg.(type) {
+	case podsMsg:
+		return msg.namespace, true
+	case pvcsMsg:
+		return msg.namespace, true
+	case deploymentsMsg:
+		return msg.namespace, true
+	case statefulsetsMsg:
+		return msg.namespace, true
+	case daemonsetsMsg:
+		return msg.namespace, true
+	case servicesMsg:
+		return msg.namespace, true
+	case networkPoliciesMsg:
+		return msg.namespace, true
+	case rolesMsg:
+		return msg.namespace, true
+	case roleBindingsMsg:
+		return msg.namespace, true
+	case resourceQuotasMsg:
+		return msg.namespace, true
+	case limitRangesMsg:
+		return msg.namespace, true
+	case pdbsMsg:
+		return msg.namespace, true
+	case cronJobsMsg:
+		return msg.namespace, true
+	case endpointSlicesMsg:
+		return msg.namespace, true
+	case customResourcesMsg:
+		return msg.namespace, true
+	}
+	return "", false
+}
+
+// markFetched stamps v's rows as fetched now from namespace.
+func (m *model) markFetched(v viewState, namespace string) {
+	if m.fetched == nil {
+		m.fetched = make(map[viewState]fetchStamp)
+	}
+	stamp := fetchStamp{at: time.Now()}
+	if !clusterScopedViews[v] {
+		stamp.namespace = namespace
+	}
+	m.fetched[v] = stamp
+}
+
+// isFresh reports whether v's cached rows belong to the selected namespace and
+// are recent enough to act on before a refresh lands.
+func (m model) isFresh(v viewState) bool {
+	stamp, ok := m.fetched[v]
+	if !ok || time.Since(stamp.at) >= refreshInterval {
+		return false
+	}
+	return clusterScopedViews[v] || stamp.namespace == m.selectedNamespace
+}
+
+// openList switches to a list view and renders its cached rows right away,
+// while fetch refreshes them in the background.
+func (m *model) openList(v viewState, fetch tea.Cmd) tea.Cmd {
+	m.setView(v)
+	m.cursor = 0
+	return fetch
+}
+
+// maxViewStack bounds the navigation history so repeated menu hops don't grow it forever.
+const maxViewStack = 20
+
+// setView switches to v, remembering the current view so esc can return to it.
+func (m *model) setView(v viewState) {
+	if v == m.view {
+		return
+	}
+	m.viewStack = append(m.viewStack, m.view)
+	if len(m.viewStack) > maxViewStack {
+		m.viewStack = m.viewStack[len(m.viewStack)-maxViewStack:]
+	}
+	logViewChange(m.view, v)
+	m.view = v
+}
+
+// popView returns to the view that was showing before the current one.
+// It does nothing at the bottom of the stack.
+func (m *model) popView() {
+	if len(m.viewStack) == 0 {
+		return
+	}
+	logViewChange(m.view, m.viewStack[len(m.viewStack)-1])
+	m.view = m.viewStack[len(m.viewStack)-1]
+	m.viewStack = m.viewStack[:len(m.viewStack)-1]
+}
+
+// backTo pops views until v is showing. It does nothing if v isn't in the
+// history, e.g. because the user navigated away while a request was running.
+func (m *model) backTo(v viewState) {
+	for i := len(m.viewStack) - 1; i >= 0; i-- {
+		if m.viewStack[i] == v {
+			logViewChange(m.view, v)
+			m.view = v
+			m.viewStack = m.viewStack[:i]
+			return
 		}
-		metricsMap := make(map[string]v1beta1.NodeMetrics)
-		metricsList, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(context.Background(), metav1.ListOptions{})
-		if err == nil {
-			for _, m := range metricsList.Items {
-				metricsMap[m.Name] = m
-			}
+	}
+}
+
+// detailsSource returns the list view the current details were opened from.
+// Views opened on top of the details (YAML, logs, prompts) see the same list.
+func (m model) detailsSource() viewState {
+	for i := len(m.viewStack) - 1; i >= 0; i-- {
+		if m.viewStack[i] != viewDetails {
+			return m.viewStack[i]
 		}
-		return nodesMsg{nodes: nodes.Items, metrics: metricsMap}
 	}
+	return m.view
+}
+
+// askConfirm switches to the confirmation view. action is run only if the
+// user answers "y"; any other answer returns to the previous view.
+func (m *model) askConfirm(prompt string, action tea.Cmd) {
+	m.confirmPrompt = prompt
+	m.confirmAction = action
+	m.setView(viewConfirm)
+}
+
+// confirmBackdrop renders what the confirmation was asked from, shown above
+// its prompt: the details, or the list. Anything else shows no backdrop, so a
+// destructive prompt is never shown over an unrelated object.
+func (m model) confirmBackdrop() string {
+	if len(m.viewStack) == 0 {
+		return ""
+	}
+	switch from := m.viewStack[len(m.viewStack)-1]; {
+	case from == viewDetails, from == viewScaling: // The scale prompt sits on the details
+		return m.details
+	case listViews[from]:
+		m.view = from
+		return m.renderViewContent()
+	}
+	return ""
+}
+
+// withTick returns m with the refresh timer armed. Arming it supersedes any
+// timer still pending, so however many messages re-arm it, only one refresh
+// loop stays live.
+func (m model) withTick() (model, tea.Cmd) {
+	m.tickSeq++
+	seq := m.tickSeq
+	return m, tea.Tick(refreshInterval, func(time.Time) tea.Msg {
+		return tickMsg{seq: seq}
+	})
 }
 
-func getPods(clientset *kubernetes.Clientset, metricsClientset *metrics.Clientset, namespace string) tea.Cmd {
+// previewScaleDown finds the pods a deployment would likely lose when scaled
+// down to replicas, so the confirmation can name them. view is the view
+// asking, which the confirmation only takes over if it's still showing.
+func previewScaleDown(clientset kubernetes.Interface, d appsv1.Deployment, replicas int32, view viewState) tea.Cmd {
 	return func() tea.Msg {
-		pods, err := clientset.CoreV1().Pods(namespace).List(context.Background(), metav1.ListOptions{})
+		ctx, cancel := apiContext()
+		defer cancel()
+		selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
 		if err != nil {
 			return errMsg{err}
 		}
-		metricsMap := make(map[string]v1beta1.PodMetrics)
-		metricsList, err := metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(context.Background(), metav1.ListOptions{})
-		if err == nil {
-			for _, m := range metricsList.Items {
-				metricsMap[m.Name] = m
-			}
+		pods, err := clientset.CoreV1().Pods(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			return errMsg{err}
+		}
+		from := int32(1)
+		if d.Spec.Replicas != nil {
+			from = *d.Spec.Replicas
+		}
+		return scaleDownPreviewMsg{
+			namespace: d.Namespace,
+			name:      d.Name,
+			from:      from,
+			to:        replicas,
+			victims:   scaleDownVictims(pods.Items, int(from-replicas)),
+			view:      view,
 		}
-		return podsMsg{pods: pods.Items, metrics: metricsMap}
 	}
 }
 
-func getPVCs(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
-	return func() tea.Msg {
-		pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(context.Background(), metav1.ListOptions{})
-		if err != nil {
-			return errMsg{err}
+// scaleTo scales d to replicas. Scaling down asks for confirmation first,
+// naming the pods it would likely terminate.
+func (m model) scaleTo(d appsv1.Deployment, replicas int32) tea.Cmd {
+	current := int32(1)
+	if d.Spec.Replicas != nil {
+		current = *d.Spec.Replicas
+	}
+	if replicas < current {
+		return previewScaleDown(m.clientset, d, replicas, m.view)
+	}
+	return scaleDeployment(m.clientset, d.Namespace, d.Name, replicas)
+}
+
+// scaleDownVictims returns the n newest live pods. The ReplicaSet controller
+// also weighs readiness and placement, but it removes the newest pods first
+// among otherwise equal ones, so this is a close approximation.
+func scaleDownVictims(pods []v1.Pod, n int) []string {
+	var live []v1.Pod
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil && pod.Status.Phase != v1.PodSucceeded && pod.Status.Phase != v1.PodFailed {
+			live = append(live, pod)
 		}
-		return pvcsMsg{pvcs.Items}
 	}
+	sort.SliceStable(live, func(i, j int) bool {
+		return live[j].CreationTimestamp.Before(&live[i].CreationTimestamp)
+	})
+	var names []string
+	for i := 0; i < n && i < len(live); i++ {
+		names = append(names, live[i].Name)
+	}
+	return names
 }
 
-func getPVs(clientset *kubernetes.Clientset) tea.Cmd {
+// workload is a Deployment, StatefulSet or DaemonSet, the kinds that share the
+// restart, delete, logs and pods actions.
+type workload struct {
+	kind      string // "deployment", "statefulset" or "daemonset", as kubectl names them
+	namespace string
+	name      string
+	selector  *metav1.LabelSelector
+}
+
+func (w workload) String() string { return w.kind + " " + w.name }
+
+// selectedWorkload returns the workload selected in list view v.
+func (m model) selectedWorkload(v viewState) (workload, bool) {
+	if m.cursor >= m.listLenOf(v) {
+		return workload{}, false
+	}
+	switch v {
+	case viewDeployments:
+		d := m.deployments[m.cursor]
+		return workload{"deployment", d.Namespace, d.Name, d.Spec.Selector}, true
+	case viewStatefulSets:
+		s := m.statefulsets[m.cursor]
+		return workload{"statefulset", s.Namespace, s.Name, s.Spec.Selector}, true
+	case viewDaemonSets:
+		d := m.daemonsets[m.cursor]
+		return workload{"daemonset", d.Namespace, d.Name, d.Spec.Selector}, true
+	}
+	return workload{}, false
+}
+
+// restartWorkload replaces all pods of a workload the way kubectl rollout
+// restart does: stamping the pod template with the restart time makes the
+// controller roll out new pods under the workload's update strategy.
+func restartWorkload(clientset kubernetes.Interface, w workload) tea.Cmd {
 	return func() tea.Msg {
-		pvs, err := clientset.CoreV1().PersistentVolumes().List(context.Background(), metav1.ListOptions{})
+		ctx, cancel := apiContext()
+		defer cancel()
+		patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{"kubectl.kubernetes.io/restartedAt":%q}}}}}`, time.Now().Format(time.RFC3339))
+		var err error
+		switch w.kind {
+		case "deployment":
+			_, err = clientset.AppsV1().Deployments(w.namespace).Patch(ctx, w.name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+		case "statefulset":
+			_, err = clientset.AppsV1().StatefulSets(w.namespace).Patch(ctx, w.name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+		case "daemonset":
+			_, err = clientset.AppsV1().DaemonSets(w.namespace).Patch(ctx, w.name, types.StrategicMergePatchType, []byte(patch), metav1.PatchOptions{})
+		}
 		if err != nil {
 			return errMsg{err}
 		}
-		return pvsMsg{pvs.Items}
+		return workloadRestartedMsg{w}
 	}
 }
 
-func getDeployments(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+// deleteWorkload deletes a workload. Its pods are garbage collected in the
+// background, as with kubectl delete.
+func deleteWorkload(clientset kubernetes.Interface, w workload) tea.Cmd {
 	return func() tea.Msg {
-		deployments, err := clientset.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
+		ctx, cancel := apiContext()
+		defer cancel()
+		var err error
+		switch w.kind {
+		case "deployment":
+			err = clientset.AppsV1().Deployments(w.namespace).Delete(ctx, w.name, metav1.DeleteOptions{})
+		case "statefulset":
+			err = clientset.AppsV1().StatefulSets(w.namespace).Delete(ctx, w.name, metav1.DeleteOptions{})
+		case "daemonset":
+			err = clientset.AppsV1().DaemonSets(w.namespace).Delete(ctx, w.name, metav1.DeleteOptions{})
+		}
 		if err != nil {
 			return errMsg{err}
 		}
-		return deploymentsMsg{deployments.Items}
+		return workloadDeletedMsg{w}
 	}
 }
 
-func getStatefulSets(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+// workloadList returns the list view of a workload kind and a command that refreshes it.
+func (m model) workloadList(kind string) (viewState, tea.Cmd) {
+	switch kind {
+	case "statefulset":
+		return viewStatefulSets, getStatefulSets(m.clientset, m.selectedNamespace)
+	case "daemonset":
+		return viewDaemonSets, getDaemonSets(m.clientset, m.selectedNamespace)
+	}
+	return viewDeployments, getDeployments(m.clientset, m.selectedNamespace)
+}
+
+func deletePod(clientset *kubernetes.Clientset, namespace, name string) tea.Cmd {
 	return func() tea.Msg {
-		statefulsets, err := clientset.AppsV1().StatefulSets(namespace).List(context.Background(), metav1.ListOptions{})
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := clientset.CoreV1().Pods(namespace).Delete(ctx, name, metav1.DeleteOptions{})
 		if err != nil {
 			return errMsg{err}
 		}
-		return statefulsetsMsg{statefulsets.Items}
+		return podDeletedMsg{}
 	}
 }
 
-func getDaemonSets(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
-	return func() tea.Msg {
-		daemonsets, err := clientset.AppsV1().DaemonSets(namespace).List(context.Background(), metav1.ListOptions{})
-		if err != nil {
-			return errMsg{err}
+// finishedPods returns the pods that have stopped for good: completed,
+// failed, or evicted (which the kubelet also marks Failed).
+func finishedPods(pods []v1.Pod) []v1.Pod {
+	var finished []v1.Pod
+	for _, p := range pods {
+		if p.Status.Phase == v1.PodSucceeded || p.Status.Phase == v1.PodFailed || p.Status.Reason == "Evicted" {
+			finished = append(finished, p)
 		}
-		return daemonsetsMsg{daemonsets.Items}
 	}
+	return finished
 }
 
-func getServices(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+// deletePods deletes pods a few at a time. A pod that's already gone counts
+// as deleted; other failures are reported once the rest have been tried.
+func deletePods(clientset kubernetes.Interface, pods []v1.Pod) tea.Cmd {
 	return func() tea.Msg {
-		services, err := clientset.CoreV1().Services(namespace).List(context.Background(), metav1.ListOptions{})
-		if err != nil {
-			return errMsg{err}
+		errs := make([]error, len(pods))
+		var g errgroup.Group
+		g.SetLimit(5)
+		for i, p := range pods {
+			g.Go(func() error {
+				ctx, cancel := apiContext() // Per pod, so long cleanups don't run out of time
+				defer cancel()
+				err := clientset.CoreV1().Pods(p.Namespace).Delete(ctx, p.Name, metav1.DeleteOptions{})
+				if err != nil && !apierrors.IsNotFound(err) {
+					errs[i] = fmt.Errorf("%s/%s: %w", p.Namespace, p.Name, err)
+				}
+				return nil
+			})
+		}
+		g.Wait()
+		if err := errors.Join(errs...); err != nil {
+			return errMsg{fmt.Errorf("deleting finished pods: %w", err)}
 		}
-		return servicesMsg{services.Items}
+		return podsDeletedMsg{len(pods)}
 	}
 }
 
-func getNetworkPolicies(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+func scaleDeployment(clientset *kubernetes.Clientset, namespace, name string, replicas int32) tea.Cmd {
 	return func() tea.Msg {
-		policies, err := clientset.NetworkingV1().NetworkPolicies(namespace).List(context.Background(), metav1.ListOptions{})
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errMsg{err}
+		}
+
+		deployment.Spec.Replicas = &replicas
+		_, err = clientset.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 		if err != nil {
 			return errMsg{err}
 		}
-		return networkPoliciesMsg{policies.Items}
+		return scaleMsg{namespace, name}
 	}
 }
 
-func getEvents(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+// createNamespace creates a namespace after checking the name is a valid
+// DNS-1123 label, so typos get a readable error instead of the API's.
+func createNamespace(clientset *kubernetes.Clientset, name string) tea.Cmd {
 	return func() tea.Msg {
-		events, err := clientset.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{})
-		if err != nil {
+		ctx, cancel := apiContext()
+		defer cancel()
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return errMsg{fmt.Errorf("invalid namespace name %q: %s", name, strings.Join(errs, "; "))}
+		}
+		ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if _, err := clientset.CoreV1().Namespaces().Create(ctx, ns, metav1.CreateOptions{}); err != nil {
 			return errMsg{err}
 		}
-		sort.Slice(events.Items, func(i, j int) bool {
-			return events.Items[i].LastTimestamp.Time.After(events.Items[j].LastTimestamp.Time)
-		})
-		return eventsMsg{events.Items}
+		return namespaceCreatedMsg{name: name}
 	}
 }
 
-func getNamespaces(clientset *kubernetes.Clientset) tea.Cmd {
+func deleteNamespace(clientset *kubernetes.Clientset, name string) tea.Cmd {
 	return func() tea.Msg {
-		ns, err := clientset.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+		ctx, cancel := apiContext()
+		defer cancel()
+		err := clientset.CoreV1().Namespaces().Delete(ctx, name, metav1.DeleteOptions{})
 		if err != nil {
 			return errMsg{err}
 		}
-		return namespacesMsg{ns.Items}
+		return namespaceDeletedMsg{name: name}
 	}
 }
 
-// getResourceYAML fetches a resource and returns its YAML representation.
-func getResourceYAML(clientset *kubernetes.Clientset, namespace, name, kind string) tea.Cmd {
+// setImage updates the image of a single container in a deployment's pod template,
+// which triggers a new rollout. This mirrors `kubectl set image`.
+func setImage(clientset *kubernetes.Clientset, namespace, name, container, image string) tea.Cmd {
 	return func() tea.Msg {
-		var obj runtime.Object
-		var err error
-
-		switch kind {
-		case "Pod":
-			obj, err = clientset.CoreV1().Pods(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "Deployment":
-			obj, err = clientset.AppsV1().Deployments(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "StatefulSet":
-			obj, err = clientset.AppsV1().StatefulSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "DaemonSet":
-			obj, err = clientset.AppsV1().DaemonSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "Service":
-			obj, err = clientset.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "PersistentVolumeClaim":
-			obj, err = clientset.CoreV1().PersistentVolumeClaims(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "PersistentVolume":
-			obj, err = clientset.CoreV1().PersistentVolumes().Get(context.Background(), name, metav1.GetOptions{})
-		case "NetworkPolicy":
-			obj, err = clientset.NetworkingV1().NetworkPolicies(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "Node":
-			obj, err = clientset.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
-		case "Event":
-			obj, err = clientset.CoreV1().Events(namespace).Get(context.Background(), name, metav1.GetOptions{})
-		case "Namespace":
-			obj, err = clientset.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
-		default:
-			return errMsg{fmt.Errorf("unsupported resource kind for YAML: %s", kind)}
-		}
-
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
 		if err != nil {
 			return errMsg{err}
 		}
 
-		// Convert to YAML
-		s := json.NewYAMLSerializer(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme)
-		var b bytes.Buffer
-		if err := s.Encode(obj, &b); err != nil {
+		found := false
+		for i := range deployment.Spec.Template.Spec.Containers {
+			if deployment.Spec.Template.Spec.Containers[i].Name == container {
+				deployment.Spec.Template.Spec.Containers[i].Image = image
+				found = true
+			}
+		}
+		if !found {
+			return errMsg{fmt.Errorf("container %q not found in deployment %s", container, name)}
+		}
+
+		_, err = clientset.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
+		if err != nil {
 			return errMsg{err}
 		}
+		return imageSetMsg{}
+	}
+}
+
+// parseImageInput parses "container=image:tag". The container name may be
+// omitted when the deployment only has one container.
+func parseImageInput(input string, d appsv1.Deployment) (container, image string, err error) {
+	input = strings.TrimSpace(input)
+	if name, img, ok := strings.Cut(input, "="); ok {
+		container, image = strings.TrimSpace(name), strings.TrimSpace(img)
+	} else if len(d.Spec.Template.Spec.Containers) == 1 {
+		container, image = d.Spec.Template.Spec.Containers[0].Name, input
+	} else {
+		return "", "", fmt.Errorf("expected container=image:tag")
+	}
+	if container == "" || image == "" {
+		return "", "", fmt.Errorf("expected container=image:tag")
+	}
+	return container, image, nil
+}
+
+// getRolloutStatus fetches the workload whose rollout viewRolloutStatus shows.
+func getRolloutStatus(clientset kubernetes.Interface, w workload) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var msg rolloutStatusMsg
+		var err error
+		switch w.kind {
+		case "statefulset":
+			msg.statefulSet, err = clientset.AppsV1().StatefulSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		case "daemonset":
+			msg.daemonSet, err = clientset.AppsV1().DaemonSets(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		default:
+			msg.deployment, err = clientset.AppsV1().Deployments(w.namespace).Get(ctx, w.name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return errMsg{refreshError{err}}
+		}
+		return msg
+	}
+}
+
+// rolloutStatus describes the rollout progress of a deployment and reports
+// whether it has completed, following the same rules as `kubectl rollout status`.
+func rolloutStatus(d *appsv1.Deployment) (string, bool) {
+	if d.Generation > d.Status.ObservedGeneration {
+		return "Waiting for deployment spec update to be observed...", false
+	}
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
+	}
+	if d.Status.UpdatedReplicas < desired {
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...", d.Name, d.Status.UpdatedReplicas, desired), false
+	}
+	if d.Status.Replicas > d.Status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...", d.Name, d.Status.Replicas-d.Status.UpdatedReplicas), false
+	}
+	if d.Status.AvailableReplicas < d.Status.UpdatedReplicas {
+		return fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...", d.Name, d.Status.AvailableReplicas, d.Status.UpdatedReplicas), false
+	}
+	return fmt.Sprintf("deployment %q successfully rolled out", d.Name), true
+}
+
+// statefulSetRolloutStatus is rolloutStatus for a StatefulSet. With a
+// partition only pods with an ordinal at or above it are updated, so the
+// rollout is done once those are, even though the rest keep the old revision.
+func statefulSetRolloutStatus(s *appsv1.StatefulSet) (string, bool) {
+	if s.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return fmt.Sprintf("statefulset %q uses the OnDelete strategy: pods are updated only when deleted (%d updated)", s.Name, s.Status.UpdatedReplicas), true
+	}
+	if s.Status.ObservedGeneration == 0 || s.Generation > s.Status.ObservedGeneration {
+		return "Waiting for statefulset spec update to be observed...", false
+	}
+	desired := int32(1)
+	if s.Spec.Replicas != nil {
+		desired = *s.Spec.Replicas
+	}
+	if s.Status.ReadyReplicas < desired {
+		return fmt.Sprintf("Waiting for %d pods to be ready...", desired-s.Status.ReadyReplicas), false
+	}
+	if partition := statefulSetPartition(s); partition > 0 {
+		toUpdate := max(desired-partition, 0)
+		if s.Status.UpdatedReplicas < toUpdate {
+			return fmt.Sprintf("Waiting for partitioned roll out to finish: %d out of %d new pods have been updated...", s.Status.UpdatedReplicas, toUpdate), false
+		}
+		return fmt.Sprintf("partitioned roll out complete: %d new pods have been updated...", s.Status.UpdatedReplicas), true
+	}
+	if s.Status.UpdateRevision != s.Status.CurrentRevision {
+		return fmt.Sprintf("Waiting for statefulset rolling update to complete %d pods at revision %s...", s.Status.UpdatedReplicas, s.Status.UpdateRevision), false
+	}
+	return fmt.Sprintf("statefulset rolling update complete %d pods at revision %s...", s.Status.CurrentReplicas, s.Status.CurrentRevision), true
+}
+
+// statefulSetPartition returns the ordinal from which a rolling update
+// replaces pods, 0 when unpartitioned.
+func statefulSetPartition(s *appsv1.StatefulSet) int32 {
+	if ru := s.Spec.UpdateStrategy.RollingUpdate; ru != nil && ru.Partition != nil {
+		return *ru.Partition
+	}
+	return 0
+}
+
+// daemonSetRolloutStatus is rolloutStatus for a DaemonSet, where the desired
+// count is the number of nodes it should run on.
+func daemonSetRolloutStatus(d *appsv1.DaemonSet) (string, bool) {
+	if d.Spec.UpdateStrategy.Type == appsv1.OnDeleteDaemonSetStrategyType {
+		return fmt.Sprintf("daemon set %q uses the OnDelete strategy: pods are updated only when deleted (%d updated)", d.Name, d.Status.UpdatedNumberScheduled), true
+	}
+	if d.Generation > d.Status.ObservedGeneration {
+		return "Waiting for daemon set spec update to be observed...", false
+	}
+	if d.Status.UpdatedNumberScheduled < d.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d out of %d new pods have been updated...", d.Name, d.Status.UpdatedNumberScheduled, d.Status.DesiredNumberScheduled), false
+	}
+	if d.Status.NumberAvailable < d.Status.DesiredNumberScheduled {
+		return fmt.Sprintf("Waiting for daemon set %q rollout to finish: %d of %d updated pods are available...", d.Name, d.Status.NumberAvailable, d.Status.DesiredNumberScheduled), false
+	}
+	return fmt.Sprintf("daemon set %q successfully rolled out", d.Name), true
+}
+
+// rolloutPollInterval is how often a watched rollout is checked.
+var rolloutPollInterval = 2 * time.Second
+
+// pollRollout fetches a deployment after rolloutPollInterval, to check on a
+// rollout kubeview started.
+func pollRollout(clientset *kubernetes.Clientset, namespace, name string) tea.Cmd {
+	return tea.Tick(rolloutPollInterval, func(time.Time) tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		return rolloutPolledMsg{namespace: namespace, name: name, deployment: deployment, err: err}
+	})
+}
+
+// rolloutOutcome reports whether a deployment's rollout has ended, and how:
+// it failed once the controller gives up after progressDeadlineSeconds.
+func rolloutOutcome(d *appsv1.Deployment) (notice string, ended, failed bool) {
+	for _, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing && c.Status == v1.ConditionFalse && c.Reason == "ProgressDeadlineExceeded" {
+			return fmt.Sprintf("Rollout of deployment %s/%s failed: %s", d.Namespace, d.Name, c.Message), true, true
+		}
+	}
+	if _, done := rolloutStatus(d); done {
+		return fmt.Sprintf("Deployment %s/%s rolled out", d.Namespace, d.Name), true, false
+	}
+	return "", false, false
+}
+
+// watchRollout starts polling a deployment's rollout, unless it's already
+// watched or rollout notifications are off.
+func (m *model) watchRollout(namespace, name string) tea.Cmd {
+	key := namespace + "/" + name
+	if !m.notifyRollouts || m.watchedRollouts[key] {
+		return nil
+	}
+	if m.watchedRollouts == nil {
+		m.watchedRollouts = make(map[string]bool)
+	}
+	m.watchedRollouts[key] = true
+	return pollRollout(m.clientset, namespace, name)
+}
 
-		return yamlMsg{yaml: b.String()}
+// bell is the terminal bell, written with the next frame.
+const bell = "\a"
+
+// deploymentHealth summarizes a deployment for the list: "Degraded" when the
+// controller reports a failure or replicas stay unavailable outside a rollout,
+// "Progressing" while a rollout is under way and "Healthy" otherwise.
+func deploymentHealth(d *appsv1.Deployment) string {
+	for _, c := range d.Status.Conditions {
+		switch {
+		case c.Type == appsv1.DeploymentProgressing && c.Status == v1.ConditionFalse, // Progress deadline exceeded
+			c.Type == appsv1.DeploymentAvailable && c.Status == v1.ConditionFalse,
+			c.Type == appsv1.DeploymentReplicaFailure && c.Status == v1.ConditionTrue:
+			return "Degraded"
+		}
+	}
+	desired := int32(1)
+	if d.Spec.Replicas != nil {
+		desired = *d.Spec.Replicas
 	}
+	if d.Generation > d.Status.ObservedGeneration || d.Status.UpdatedReplicas < desired || d.Status.Replicas > d.Status.UpdatedReplicas {
+		return "Progressing"
+	}
+	if d.Status.AvailableReplicas < desired {
+		return "Degraded"
+	}
+	return "Healthy"
 }
 
-// getDashboardMetrics fetches and aggregates cluster-wide resource utilization metrics.
-func getDashboardMetrics(clientset *kubernetes.Clientset, metricsClientset *metrics.Clientset) tea.Cmd {
+// rollbackDeployment restores a deployment's pod template from the ReplicaSet of
+// its previous revision, like `kubectl rollout undo`.
+func rollbackDeployment(clientset *kubernetes.Clientset, namespace, name string) tea.Cmd {
 	return func() tea.Msg {
-		var totalCPUCapacity, totalMemoryCapacity resource.Quantity
-		var totalCPUUsage, totalMemoryUsage resource.Quantity
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errMsg{err}
+		}
+		rsList, err := clientset.AppsV1().ReplicaSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errMsg{err}
+		}
 
-		// Get Nodes and Node Metrics
-		nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+		previous, err := previousReplicaSet(deployment, rsList.Items)
 		if err != nil {
 			return errMsg{err}
 		}
-		nodeMetricsList, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(context.Background(), metav1.ListOptions{})
+
+		template := previous.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+		deployment.Spec.Template = *template
+		_, err = clientset.AppsV1().Deployments(namespace).Update(ctx, deployment, metav1.UpdateOptions{})
 		if err != nil {
 			return errMsg{err}
 		}
-		nodeMetricsMap := make(map[string]v1beta1.NodeMetrics)
-		for _, nm := range nodeMetricsList.Items {
-			nodeMetricsMap[nm.Name] = nm
+		return rollbackMsg{}
+	}
+}
+
+// previousReplicaSet returns the ReplicaSet owned by d with the highest
+// revision below the deployment's current revision.
+func previousReplicaSet(d *appsv1.Deployment, replicaSets []appsv1.ReplicaSet) (*appsv1.ReplicaSet, error) {
+	const revisionAnnotation = "deployment.kubernetes.io/revision"
+
+	current, err := strconv.ParseInt(d.Annotations[revisionAnnotation], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("deployment %s has no valid revision annotation", d.Name)
+	}
+
+	var previous *appsv1.ReplicaSet
+	var previousRevision int64
+	for i := range replicaSets {
+		rs := &replicaSets[i]
+		if !metav1.IsControlledBy(rs, d) {
+			continue
+		}
+		revision, err := strconv.ParseInt(rs.Annotations[revisionAnnotation], 10, 64)
+		if err != nil || revision >= current {
+			continue
+		}
+		if previous == nil || revision > previousRevision {
+			previous = rs
+			previousRevision = revision
+		}
+	}
+	if previous == nil {
+		return nil, fmt.Errorf("no previous revision found for deployment %s", d.Name)
+	}
+	return previous, nil
+}
+
+// canIQuery is a parsed `kubectl auth can-i` style question.
+type canIQuery struct {
+	verb           string
+	resource       string
+	subresource    string
+	group          string
+	namespace      string
+	serviceAccount string // "namespace:name"; empty checks the current user
+}
+
+// parseCanIQuery parses "VERB RESOURCE[.GROUP][/SUBRESOURCE] [-n NAMESPACE] [--as NAMESPACE:SERVICEACCOUNT]".
+func parseCanIQuery(input, defaultNamespace string) (canIQuery, error) {
+	q := canIQuery{namespace: defaultNamespace}
+	var args []string
+	fields := strings.Fields(input)
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "-n", "--namespace":
+			if i+1 >= len(fields) {
+				return q, fmt.Errorf("%s requires a namespace", fields[i])
+			}
+			i++
+			q.namespace = fields[i]
+		case "--as":
+			if i+1 >= len(fields) {
+				return q, fmt.Errorf("--as requires NAMESPACE:SERVICEACCOUNT")
+			}
+			i++
+			if !strings.Contains(fields[i], ":") {
+				return q, fmt.Errorf("--as expects NAMESPACE:SERVICEACCOUNT, got %q", fields[i])
+			}
+			q.serviceAccount = fields[i]
+		default:
+			args = append(args, fields[i])
+		}
+	}
+	if len(args) != 2 {
+		return q, fmt.Errorf("expected VERB RESOURCE, e.g. \"list pods\"")
+	}
+	q.verb = args[0]
+	q.resource, q.subresource, _ = strings.Cut(args[1], "/")
+	q.resource, q.group, _ = strings.Cut(q.resource, ".")
+	return q, nil
+}
+
+// checkAccess asks the API server whether the current user, or the given
+// service account, may perform the query, mirroring `kubectl auth can-i`.
+func checkAccess(clientset *kubernetes.Clientset, query string, q canIQuery) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		attrs := &authorizationv1.ResourceAttributes{
+			Namespace:   q.namespace,
+			Verb:        q.verb,
+			Group:       q.group,
+			Resource:    q.resource,
+			Subresource: q.subresource,
 		}
 
-		// Aggregate Node Capacity and Usage
-		for _, node := range nodes.Items {
-			totalCPUCapacity.Add(*node.Status.Capacity.Cpu())
-			totalMemoryCapacity.Add(*node.Status.Capacity.Memory())
-			if nm, ok := nodeMetricsMap[node.Name]; ok {
-				totalCPUUsage.Add(*nm.Usage.Cpu())
-				totalMemoryUsage.Add(*nm.Usage.Memory())
+		var status authorizationv1.SubjectAccessReviewStatus
+		if q.serviceAccount == "" {
+			review, err := clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx,
+				&authorizationv1.SelfSubjectAccessReview{Spec: authorizationv1.SelfSubjectAccessReviewSpec{ResourceAttributes: attrs}},
+				metav1.CreateOptions{})
+			if err != nil {
+				return errMsg{err}
+			}
+			status = review.Status
+		} else {
+			saNamespace, saName, _ := strings.Cut(q.serviceAccount, ":")
+			review, err := clientset.AuthorizationV1().SubjectAccessReviews().Create(ctx,
+				&authorizationv1.SubjectAccessReview{Spec: authorizationv1.SubjectAccessReviewSpec{
+					ResourceAttributes: attrs,
+					User:               fmt.Sprintf("system:serviceaccount:%s:%s", saNamespace, saName),
+					Groups:             []string{"system:serviceaccounts", "system:serviceaccounts:" + saNamespace, "system:authenticated"},
+				}},
+				metav1.CreateOptions{})
+			if err != nil {
+				return errMsg{err}
 			}
+			status = review.Status
+		}
+
+		reason := status.Reason
+		if status.EvaluationError != "" {
+			reason = strings.TrimSpace(reason + " " + status.EvaluationError)
 		}
+		return canIMsg{query: query, allowed: status.Allowed, reason: reason}
+	}
+}
 
-		// Get Pods and Pod Metrics
-		pods, err := clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{}) // All namespaces
+func getLogs(clientset *kubernetes.Clientset, namespace, podName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		podLogOpts := v1.PodLogOptions{}
+		req := clientset.CoreV1().Pods(namespace).GetLogs(podName, &podLogOpts)
+		podLogs, err := req.Stream(ctx)
 		if err != nil {
 			return errMsg{err}
 		}
-		podMetricsList, err := metricsClientset.MetricsV1beta1().PodMetricses("").List(context.Background(), metav1.ListOptions{}) // All namespaces
+		defer podLogs.Close()
+
+		var buf bytes.Buffer
+		_, err = io.Copy(&buf, podLogs)
 		if err != nil {
 			return errMsg{err}
 		}
-		podMetricsMap := make(map[string]v1beta1.PodMetrics)
-		for _, pm := range podMetricsList.Items {
-			podMetricsMap[pm.Name] = pm
+		return logsMsg{logs: buf.String(), source: podName}
+	}
+}
+
+// getPreviousLogs fetches the logs of the previous instance of every restarted
+// container in pod, each under a header saying why that instance ended, like
+// kubectl logs --previous with the pod's last termination state alongside.
+func getPreviousLogs(clientset kubernetes.Interface, pod v1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		source := pod.Name + " (previous)"
+		var b strings.Builder
+		for _, cs := range pod.Status.ContainerStatuses {
+			if cs.LastTerminationState.Terminated == nil {
+				continue // Never restarted, so there's no previous instance
+			}
+			b.WriteString(terminationSummary(cs))
+			out, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: cs.Name, Previous: true}).DoRaw(ctx)
+			if err != nil {
+				b.WriteString("error: " + err.Error() + "\n\n")
+				continue
+			}
+			b.WriteString(string(out))
+			if len(out) > 0 && out[len(out)-1] != '\n' {
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+		if b.Len() == 0 {
+			return logsMsg{logs: fmt.Sprintf("No container in pod %s has restarted, so there are no previous logs.\n", pod.Name), source: source}
 		}
+		return logsMsg{logs: b.String(), source: source}
+	}
+}
 
-		// Prepare for sorting top pods/nodes
-		type podWithMetrics struct {
-			v1.Pod
-			CPUUsage    *resource.Quantity
-			MemoryUsage *resource.Quantity
+// defaultLogGrepTailLines pre-fills the tail count of the log grep prompt.
+const defaultLogGrepTailLines = 1000
+
+// parseLogGrep parses the log grep prompt, a tail count followed by a regular
+// expression, e.g. "500 timeout|refused".
+func parseLogGrep(input string) (int64, *regexp.Regexp, error) {
+	count, pattern, _ := strings.Cut(strings.TrimSpace(input), " ")
+	tail, err := strconv.ParseInt(count, 10, 64)
+	if err != nil || tail <= 0 {
+		return 0, nil, fmt.Errorf("invalid tail count %q, expected e.g. \"%d error\"", count, defaultLogGrepTailLines)
+	}
+	pattern = strings.TrimSpace(pattern)
+	if pattern == "" {
+		return 0, nil, errors.New("enter a pattern to search the logs for after the tail count")
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return 0, nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return tail, re, nil
+}
+
+// getGrepLogs fetches the last tail lines of every container in pod, keeping
+// only those matching re, like kubectl logs --tail=N | grep. Lines are matched
+// as they're streamed, so a noisy pod's log is never held in full.
+func getGrepLogs(clientset kubernetes.Interface, pod v1.Pod, tail int64, re *regexp.Regexp) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		source := fmt.Sprintf("%s (last %d lines matching %q)", pod.Name, tail, re.String())
+		var b strings.Builder
+		matches := 0
+		for _, c := range pod.Spec.Containers {
+			prefix := ""
+			if len(pod.Spec.Containers) > 1 {
+				prefix = "[" + c.Name + "] "
+			}
+			stream, err := clientset.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &v1.PodLogOptions{Container: c.Name, TailLines: &tail}).Stream(ctx)
+			if err != nil {
+				b.WriteString(prefix + "error: " + err.Error() + "\n")
+				continue
+			}
+			scanner := bufio.NewScanner(stream)
+			scanner.Buffer(nil, 1024*1024) // Tolerate long JSON log lines
+			for scanner.Scan() {
+				if line := scanner.Text(); re.MatchString(line) {
+					b.WriteString(prefix + line + "\n")
+					matches++
+				}
+			}
+			if err := scanner.Err(); err != nil {
+				b.WriteString(prefix + "error: " + err.Error() + "\n")
+			}
+			stream.Close()
 		}
-		type nodeWithMetrics struct {
-			v1.Node
-			CPUUsage    *resource.Quantity
-			MemoryUsage *resource.Quantity
+		if matches == 0 && b.Len() == 0 {
+			return logsMsg{logs: fmt.Sprintf("No line in the last %d of pod %s's logs matches %q.\n", tail, pod.Name, re.String()), source: source}
+		}
+		return logsMsg{logs: b.String(), source: source}
+	}
+}
+
+// terminationSummary describes how a container's previous instance ended,
+// e.g. "OOMKilled (exit code 137)", as a header for its previous logs.
+func terminationSummary(cs v1.ContainerStatus) string {
+	t := cs.LastTerminationState.Terminated
+	reason := t.Reason
+	if reason == "" {
+		reason = "Terminated"
+	}
+	exit := fmt.Sprintf("exit code %d", t.ExitCode)
+	if t.Signal != 0 {
+		exit += fmt.Sprintf(", signal %d", t.Signal)
+	}
+	summary := fmt.Sprintf("=== Container %s, restarted %d time(s): last instance ended with %s (%s)", cs.Name, cs.RestartCount, reason, exit)
+	if !t.FinishedAt.IsZero() {
+		summary += fmt.Sprintf(" at %s, %s ago", t.FinishedAt.Time.Format(time.RFC1123), formatAge(t.FinishedAt))
+	}
+	summary += "\n"
+	if t.Message != "" {
+		summary += "=== " + strings.TrimSpace(t.Message) + "\n"
+	}
+	return summary + "\n"
+}
+
+// workloadLogTailLines bounds how much each container contributes to the
+// aggregated workload logs, which would otherwise grow with every replica.
+const workloadLogTailLines = 200
+
+// getWorkloadLogs fetches the recent logs of every container in the pods
+// matching selector and merges them, prefixing each line with its source, like
+// kubectl logs deploy/NAME --all-containers --prefix across all replicas.
+func getWorkloadLogs(clientset *kubernetes.Clientset, namespace string, selector *metav1.LabelSelector) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		labelSelector := metav1.FormatLabelSelector(selector)
+		source := "pods matching " + labelSelector
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: labelSelector,
+		})
+		if err != nil {
+			return errMsg{err}
 		}
+		sort.Slice(pods.Items, func(i, j int) bool { return pods.Items[i].Name < pods.Items[j].Name })
 
-		var podsWithMetrics []podWithMetrics
+		type logSource struct{ pod, container string }
+		var sources []logSource
 		for _, pod := range pods.Items {
-			if pm, ok := podMetricsMap[pod.Name]; ok {
-				podsWithMetrics = append(podsWithMetrics, podWithMetrics{
-					Pod:         pod,
-					CPUUsage:    totalPodCPU(pm),
-					MemoryUsage: totalPodMemory(pm),
-				})
+			for _, c := range pod.Spec.Containers {
+				sources = append(sources, logSource{pod.Name, c.Name})
 			}
 		}
 
-		var nodesWithMetrics []nodeWithMetrics
-		for _, node := range nodes.Items {
-			if nm, ok := nodeMetricsMap[node.Name]; ok {
-				nodesWithMetrics = append(nodesWithMetrics, nodeWithMetrics{
-					Node:        node,
-					CPUUsage:    nm.Usage.Cpu(),
-					MemoryUsage: nm.Usage.Memory(),
-				})
+		// Fetch concurrently; a failing pod (e.g. still pending) gets an error
+		// line instead of hiding the logs of the others.
+		logs := make([]string, len(sources))
+		var g errgroup.Group
+		g.SetLimit(5)
+		for i, src := range sources {
+			g.Go(func() error {
+				prefix := fmt.Sprintf("[%s/%s] ", src.pod, src.container)
+				tail := int64(workloadLogTailLines)
+				out, err := clientset.CoreV1().Pods(namespace).GetLogs(src.pod, &v1.PodLogOptions{Container: src.container, TailLines: &tail}).DoRaw(ctx)
+				if err != nil {
+					logs[i] = prefix + "error: " + err.Error() + "\n"
+					return nil
+				}
+				logs[i] = prefixLines(string(out), prefix)
+				return nil
+			})
+		}
+		g.Wait()
+
+		if len(sources) == 0 {
+			return logsMsg{logs: "No pods match the workload's selector.\n", source: source}
+		}
+		return logsMsg{logs: strings.Join(logs, ""), source: source}
+	}
+}
+
+// prefixLines prefixes every line of s with prefix.
+func prefixLines(s, prefix string) string {
+	if s == "" {
+		return ""
+	}
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = prefix + line
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+func getNodes(clientset *kubernetes.Clientset, metricsClientset *metrics.Clientset) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewNodes, "", err)
+		}
+		metricsMap := make(map[string]v1beta1.NodeMetrics)
+		metricsList, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+		if err == nil {
+			for _, m := range metricsList.Items {
+				metricsMap[m.Name] = m
 			}
 		}
+		// Like metrics, allocations are optional: without access to pods in
+		// every namespace the list still shows, with "---" in their columns
+		var allocations map[string]nodeAllocation
+		pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+		if err == nil {
+			allocations = groupPodsByNode(pods.Items)
+		}
+		return nodesMsg{nodes: nodes.Items, metrics: metricsMap, allocations: allocations}
+	}
+}
 
-		// Sort pods by CPU usage
-		podsByCPU := make([]podWithMetrics, len(podsWithMetrics))
-		copy(podsByCPU, podsWithMetrics)
-		sort.Slice(podsByCPU, func(i, j int) bool {
-			return podsByCPU[i].CPUUsage.Cmp(*podsByCPU[j].CPUUsage) > 0
-		})
+func getPods(clientset *kubernetes.Clientset, metricsClientset *metrics.Clientset, namespace string, opts metav1.ListOptions) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, opts)
+		if err != nil {
+			return listError(viewPods, namespace, err)
+		}
+		metricsMap := make(map[string]v1beta1.PodMetrics)
+		metricsList, err := metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+		if err == nil {
+			for _, m := range metricsList.Items {
+				metricsMap[m.Name] = m
+			}
+		}
+		return podsMsg{pods: pods.Items, metrics: metricsMap, namespace: namespace}
+	}
+}
 
-		// Sort pods by Memory usage
-		podsByMemory := make([]podWithMetrics, len(podsWithMetrics))
-		copy(podsByMemory, podsWithMetrics)
-		sort.Slice(podsByMemory, func(i, j int) bool {
-			return podsByMemory[i].MemoryUsage.Cmp(*podsByMemory[j].MemoryUsage) > 0
-		})
+// fetchPods fetches the Pods view, honouring the pod filter if one is set.
+func (m model) fetchPods() tea.Cmd {
+	if f := m.podFilter; f != nil {
+		return getPods(m.clientset, m.metricsClientset, f.namespace, metav1.ListOptions{LabelSelector: f.selector, FieldSelector: f.field})
+	}
+	return getPods(m.clientset, m.metricsClientset, m.selectedNamespace, metav1.ListOptions{})
+}
 
-		// Sort nodes by CPU usage
-		nodesByCPU := make([]nodeWithMetrics, len(nodesWithMetrics))
-		copy(nodesByCPU, nodesWithMetrics)
-		sort.Slice(nodesByCPU, func(i, j int) bool {
-			return nodesByCPU[i].CPUUsage.Cmp(*nodesByCPU[j].CPUUsage) > 0
-		})
+// nodeMapRow is a row of the Node Map view: a node, or one of its pods when
+// pod is set. Pods not scheduled yet are grouped under node "".
+type nodeMapRow struct {
+	node        string
+	allocatable v1.ResourceList // Node rows only
+	pods        int             // Node rows only
+	pod         *v1.Pod
+	cpu, memory *resource.Quantity // Usage, nil without metrics
+}
+
+// getNodeMap lists every node and all pods in one call each, and groups the
+// pods by spec.nodeName with their usage, to show how the scheduler placed them.
+func getNodeMap(clientset *kubernetes.Clientset, metricsClientset *metrics.Clientset) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewNodeMap, "", err)
+		}
+		pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewNodeMap, "", err)
+		}
+		usage := make(map[string]v1beta1.PodMetrics)
+		if metricsList, err := metricsClientset.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{}); err == nil {
+			for _, pm := range metricsList.Items {
+				usage[pm.Namespace+"/"+pm.Name] = pm
+			}
+		}
+		return nodeMapMsg{buildNodeMap(nodes.Items, pods.Items, usage)}
+	}
+}
+
+// buildNodeMap orders nodes by name, each followed by its running pods by
+// namespace and name. Node usage is the sum of its pods' usage.
+func buildNodeMap(nodes []v1.Node, pods []v1.Pod, usage map[string]v1beta1.PodMetrics) []nodeMapRow {
+	byNode := make(map[string][]nodeMapRow)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
+		}
+		row := nodeMapRow{node: pod.Spec.NodeName, pod: pod}
+		if pm, ok := usage[pod.Namespace+"/"+pod.Name]; ok {
+			row.cpu, row.memory = totalPodCPU(pm), totalPodMemory(pm)
+		}
+		byNode[pod.Spec.NodeName] = append(byNode[pod.Spec.NodeName], row)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+	groups := make([]nodeMapRow, 0, len(nodes)+1)
+	for _, n := range nodes {
+		groups = append(groups, nodeMapRow{node: n.Name, allocatable: n.Status.Allocatable})
+	}
+	if len(byNode[""]) > 0 {
+		groups = append(groups, nodeMapRow{}) // Pending pods
+	}
 
-		// Sort nodes by Memory usage
-		nodesByMemory := make([]nodeWithMetrics, len(nodesWithMetrics))
-		copy(nodesByMemory, nodesWithMetrics)
-		sort.Slice(nodesByMemory, func(i, j int) bool {
-			return nodesByMemory[i].MemoryUsage.Cmp(*nodesByMemory[j].MemoryUsage) > 0
+	var rows []nodeMapRow
+	for _, g := range groups {
+		podRows := byNode[g.node]
+		sort.Slice(podRows, func(i, j int) bool {
+			a, b := podRows[i].pod, podRows[j].pod
+			return a.Namespace+"/"+a.Name < b.Namespace+"/"+b.Name
 		})
+		g.pods = len(podRows)
+		for _, p := range podRows {
+			if p.cpu != nil {
+				if g.cpu == nil {
+					g.cpu, g.memory = resource.NewQuantity(0, resource.DecimalSI), resource.NewQuantity(0, resource.BinarySI)
+				}
+				g.cpu.Add(*p.cpu)
+				g.memory.Add(*p.memory)
+			}
+		}
+		rows = append(rows, g)
+		rows = append(rows, podRows...)
+	}
+	return rows
+}
+
+// countedKinds are the columns of the Resource Counts view.
+var countedKinds = []struct {
+	header string
+	gvr    schema.GroupVersionResource
+}{
+	{"PODS", v1.SchemeGroupVersion.WithResource("pods")},
+	{"DEPLOY", appsv1.SchemeGroupVersion.WithResource("deployments")},
+	{"STS", appsv1.SchemeGroupVersion.WithResource("statefulsets")},
+	{"DS", appsv1.SchemeGroupVersion.WithResource("daemonsets")},
+	{"JOBS", batchv1.SchemeGroupVersion.WithResource("jobs")},
+	{"CRONJOBS", batchv1.SchemeGroupVersion.WithResource("cronjobs")},
+	{"SVC", v1.SchemeGroupVersion.WithResource("services")},
+	{"CM", v1.SchemeGroupVersion.WithResource("configmaps")},
+	{"SECRETS", v1.SchemeGroupVersion.WithResource("secrets")},
+	{"PVC", v1.SchemeGroupVersion.WithResource("persistentvolumeclaims")},
+}
+
+// resourceCountRow is a row of the Resource Counts view, counts following
+// countedKinds. A count of -1 means that kind couldn't be listed. The total
+// row has an empty namespace.
+type resourceCountRow struct {
+	namespace string
+	counts    []int
+}
 
-		// Get top N (e.g., 5)
-		topN := 5
-		var topPodsCPU, topPodsMem []v1.Pod
-		for i := 0; i < len(podsByCPU) && i < topN; i++ {
-			topPodsCPU = append(topPodsCPU, podsByCPU[i].Pod)
+// getResourceCounts lists each of countedKinds across all namespaces, served
+// from the API server's cache, and counts the items per namespace. Only object
+// metadata is listed, so counting never transfers pod specs or secret data. A
+// kind the user can't list shows as unknown instead of failing the whole view.
+func getResourceCounts(metadataClient metadata.Interface) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		namespaces := make([][]string, len(countedKinds))
+		errs := make([]error, len(countedKinds))
+		var g errgroup.Group
+		for i, k := range countedKinds {
+			g.Go(func() error {
+				list, err := metadataClient.Resource(k.gvr).List(ctx, metav1.ListOptions{ResourceVersion: "0"})
+				if err != nil {
+					errs[i] = err
+					return nil
+				}
+				for _, item := range list.Items {
+					namespaces[i] = append(namespaces[i], item.Namespace)
+				}
+				return nil
+			})
 		}
-		for i := 0; i < len(podsByMemory) && i < topN; i++ {
-			topPodsMem = append(topPodsMem, podsByMemory[i].Pod)
+		g.Wait()
+		failed := 0
+		for _, err := range errs {
+			if err != nil {
+				failed++
+			}
+		}
+		if failed == len(countedKinds) {
+			return listError(viewResourceCounts, "", errs[0])
 		}
+		return resourceCountsMsg{buildResourceCounts(namespaces, errs)}
+	}
+}
 
-		var topNodesCPU, topNodesMem []v1.Node
-		for i := 0; i < len(nodesByCPU) && i < topN; i++ {
-			topNodesCPU = append(topNodesCPU, nodesByCPU[i].Node)
+// buildResourceCounts turns the namespaces of each kind's items into one row
+// per namespace, sorted by name, after a row of cluster-wide totals.
+func buildResourceCounts(namespaces [][]string, errs []error) []resourceCountRow {
+	total := resourceCountRow{counts: make([]int, len(namespaces))}
+	byNamespace := map[string]resourceCountRow{}
+	for i, items := range namespaces {
+		if errs[i] != nil {
+			total.counts[i] = -1
+			continue
 		}
-		for i := 0; i < len(nodesByMemory) && i < topN; i++ {
-			topNodesMem = append(topNodesMem, nodesByMemory[i].Node)
+		total.counts[i] = len(items)
+		for _, ns := range items {
+			row, ok := byNamespace[ns]
+			if !ok {
+				row = resourceCountRow{namespace: ns, counts: make([]int, len(namespaces))}
+				byNamespace[ns] = row
+			}
+			row.counts[i]++
 		}
+	}
 
-		return dashboardMsg{
-			clusterCPUUsage:    fmt.Sprintf("%s / %s (%s%%)", formatMilliCPU(&totalCPUUsage), formatMilliCPU(&totalCPUCapacity), formatPercentage(totalCPUUsage.MilliValue(), totalCPUCapacity.MilliValue())),
-			clusterMemoryUsage: fmt.Sprintf("%s / %s (%s%%)", formatMiBMemory(&totalMemoryUsage), formatMiBMemory(&totalMemoryCapacity), formatPercentage(totalMemoryUsage.Value(), totalMemoryCapacity.Value())),
-			topPodsByCPU:       topPodsCPU,
-			topPodsByMemory:    topPodsMem,
-			topNodesByCPU:      topNodesCPU,
-			topNodesByMemory:   topNodesMem,
+	rows := []resourceCountRow{total}
+	for _, row := range byNamespace {
+		for i := range row.counts {
+			if errs[i] != nil {
+				row.counts[i] = -1
+			}
 		}
+		rows = append(rows, row)
 	}
+	sort.Slice(rows[1:], func(i, j int) bool { return rows[i+1].namespace < rows[j+1].namespace })
+	return rows
 }
 
-func (m model) Init() tea.Cmd {
-	return tea.Batch(getNodes(m.clientset, m.metricsClientset), doTick())
+// showWorkloadPods opens the Pods view filtered to the pods matched by a
+// workload's selector. The filter stays until the view is left with esc.
+func (m *model) showWorkloadPods(kind, namespace, name string, selector *metav1.LabelSelector) tea.Cmd {
+	if selector == nil {
+		return func() tea.Msg { return errMsg{fmt.Errorf("%s/%s has no pod selector", kind, name)} }
+	}
+	sel, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return func() tea.Msg { return errMsg{err} }
+	}
+	return m.showFilteredPods(podFilter{owner: kind + "/" + name, namespace: namespace, selector: sel.String()})
 }
 
-func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var (
-		cmd  tea.Cmd
-		cmds []tea.Cmd
-	)
+// showNodePods opens the Pods view filtered to the pods scheduled on a node,
+// across all namespaces.
+func (m *model) showNodePods(node string) tea.Cmd {
+	field := fields.OneTermEqualSelector("spec.nodeName", node).String()
+	return m.showFilteredPods(podFilter{owner: "node/" + node, field: field})
+}
 
-	switch msg := msg.(type) {
-	case tea.WindowSizeMsg:
-		headerHeight := lipgloss.Height(m.headerView())
-		footerHeight := lipgloss.Height(m.footerView())
-		verticalMarginHeight := headerHeight + footerHeight
+func (m *model) showFilteredPods(f podFilter) tea.Cmd {
+	m.podFilter = &f
+	m.pods = nil
+	delete(m.fetched, viewPods) // Cached rows may be unfiltered
+	m.setView(viewPods)
+	m.cursor = 0
+	return m.fetchPods()
+}
 
-		if !m.ready {
-			m.viewport = viewport.New(msg.Width, msg.Height-verticalMarginHeight)
-			m.viewport.YPosition = headerHeight
-			m.ready = true
-		} else {
-			m.viewport.Width = msg.Width
-			m.viewport.Height = msg.Height - verticalMarginHeight
+// selectRowTarget moves the cursor to the resource named by rowTarget in the
+// current list, then forgets the target. It fails if the list doesn't hold
+// the target, so actions don't fall on whatever row the cursor is on. Lists
+// whose rows selectedResource doesn't cover just drop the target.
+func (m *model) selectRowTarget() error {
+	t := m.rowTarget
+	m.rowTarget = nil
+	if m.view == viewCustomResources {
+		return nil
+	}
+	cursor := m.cursor
+	for i := range m.listLenOf(m.view) {
+		m.cursor = i
+		if ref, ok := m.selectedResource(m.view); ok && ref.kind == t.kind && ref.namespace == t.namespace && ref.name == t.name {
+			return nil
 		}
-	case tickMsg:
-		switch m.view {
-		case viewNodes:
-			return m, getNodes(m.clientset, m.metricsClientset)
-		case viewPods:
-			return m, getPods(m.clientset, m.metricsClientset, m.selectedNamespace)
-		case viewPVCs:
-			return m, getPVCs(m.clientset, m.selectedNamespace)
-		case viewPVs:
-			return m, getPVs(m.clientset)
-		case viewDeployments:
-			return m, getDeployments(m.clientset, m.selectedNamespace)
-		case viewStatefulSets:
-			return m, getStatefulSets(m.clientset, m.selectedNamespace)
-		case viewDaemonSets:
-			return m, getDaemonSets(m.clientset, m.selectedNamespace)
-		case viewServices:
-			return m, getServices(m.clientset, m.selectedNamespace)
-		case viewNetworkPolicies:
-			return m, getNetworkPolicies(m.clientset, m.selectedNamespace)
-		case viewEvents:
-			return m, getEvents(m.clientset, m.selectedNamespace)
-		case viewDashboard:
-			return m, getDashboardMetrics(m.clientset, m.metricsClientset)
+	}
+	m.cursor = cursor
+	return fmt.Errorf("%s %s/%s is not in the list", t.kind, t.namespace, t.name)
+}
+
+// listedView returns the list view whose rows msg replaces: fetchedView's,
+// plus the uncached Events and custom resources, which can hold a row target
+// too.
+func listedView(msg tea.Msg) (viewState, bool) {
+	switch msg.(type) {
+	case eventsMsg:
+		return viewEvents, true
+	case customResourcesMsg:
+		return viewCustomResources, true
+	}
+	return fetchedView(msg)
+}
+
+// showResource opens the list view for target's kind with target selected.
+// It reports false for kinds kubeview has no list view for.
+func (m *model) showResource(target controllerMsg) (tea.Cmd, bool) {
+	var fetch tea.Cmd
+	var v viewState
+	switch target.kind {
+	case "Pod":
+		m.clearPodFilter()
+		v, fetch = viewPods, m.fetchPods()
+	case "Deployment":
+		v, fetch = viewDeployments, getDeployments(m.clientset, m.selectedNamespace)
+	case "StatefulSet":
+		v, fetch = viewStatefulSets, getStatefulSets(m.clientset, m.selectedNamespace)
+	case "DaemonSet":
+		v, fetch = viewDaemonSets, getDaemonSets(m.clientset, m.selectedNamespace)
+	case "CronJob":
+		v, fetch = viewCronJobs, getCronJobs(m.clientset, m.selectedNamespace)
+	case "Service":
+		v, fetch = viewServices, getServices(m.clientset, m.selectedNamespace)
+	default:
+		return nil, false
+	}
+	if m.selectedNamespace != "" && target.namespace != m.selectedNamespace {
+		// The pod came from another namespace, e.g. through the node filter
+		m.selectedNamespace = target.namespace
+		m.stopEventWatch() // The watch is scoped to the old namespace
+		return m.showResource(target)
+	}
+	m.rowTarget = &target
+	fresh := m.isFresh(v)
+	cmd := m.openList(v, fetch)
+	if fresh { // The cached rows can be searched now, without waiting for the refresh
+		if err := m.selectRowTarget(); err != nil {
+			return tea.Batch(cmd, func() tea.Msg { return errMsg{err} }), true
 		}
-		return m, doTick()
-	case logsMsg:
-		m.viewport.SetContent(msg.logs)
-		m.view = viewLogs
-		return m, nil
-	case scaleMsg:
-		m.view = viewDetails
-		return m, getDeployments(m.clientset, m.selectedNamespace)
-	case podDeletedMsg:
-		m.view = viewPods
-		return m, getPods(m.clientset, m.metricsClientset, m.selectedNamespace)
-	case namespacesMsg:
-		m.namespaces = msg.namespaces
-		m.cursor = 0
-		return m, nil
-	case nodesMsg:
-		m.nodes = msg.nodes
-		m.nodeMetrics = msg.metrics
-		if m.cursor >= len(m.nodes) {
-			m.cursor = 0
+	}
+	return cmd, true
+}
+
+func (m *model) clearPodFilter() {
+	if m.podFilter != nil {
+		m.podFilter = nil
+		delete(m.fetched, viewPods) // Cached rows are filtered
+	}
+}
+
+// forbiddenMsg reports that the user may not list a view's resource.
+type forbiddenMsg struct {
+	view      viewState
+	resource  string // e.g. "pods" or "deployments.apps", from the API error
+	namespace string // "" when listed cluster-wide
+	err       error
+}
+
+func (f forbiddenMsg) Error() string {
+	where := "cluster-wide"
+	if f.namespace != "" {
+		where = "in namespace " + f.namespace
+	}
+	return fmt.Sprintf("you don't have permission to list %s %s", f.resource, where)
+}
+
+// listError turns a failed list for view v into a message. Forbidden lists are
+// shown in place of the view's rows, since retrying won't help and the rest of
+// the app stays usable; other errors go to the banner.
+func listError(v viewState, namespace string, err error) tea.Msg {
+	if !apierrors.IsForbidden(err) {
+		if v == viewNamespaces {
+			return errMsg{err} // Only listed on entry, so it isn't retried
 		}
-		return m, doTick()
-	case podsMsg:
-		m.pods = msg.pods
-		m.podMetrics = msg.metrics
-		if m.cursor >= len(m.pods) {
-			m.cursor = 0
+		return errMsg{refreshError{err}}
+	}
+	resource := "these resources"
+	var status apierrors.APIStatus
+	if errors.As(err, &status) && status.Status().Details != nil && status.Status().Details.Kind != "" {
+		resource = status.Status().Details.Kind
+	}
+	return forbiddenMsg{view: v, resource: resource, namespace: namespace, err: err}
+}
+
+func getPVCs(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		pvcs, err := clientset.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewPVCs, namespace, err)
 		}
-		return m, doTick()
-	case pvcsMsg:
-		m.pvcs = msg.pvcs
-		m.cursor = 0
-		return m, doTick()
-	case pvsMsg:
-		m.pvs = msg.pvs
-		m.cursor = 0
-		return m, doTick()
-	case deploymentsMsg:
-		m.deployments = msg.deployments
-		m.cursor = 0
-		return m, doTick()
-	case statefulsetsMsg:
-		m.statefulsets = msg.statefulsets
-		m.cursor = 0
-		return m, doTick()
-	case daemonsetsMsg:
-		m.daemonsets = msg.daemonsets
-		m.cursor = 0
-		return m, doTick()
-	case servicesMsg:
-		m.services = msg.services
-		m.cursor = 0
-		return m, doTick()
-	case networkPoliciesMsg:
-		m.netpols = msg.policies
-		m.cursor = 0
-		return m, doTick()
-	case eventsMsg:
-		m.events = msg.events
-		m.cursor = 0
-		return m, doTick()
-	case errMsg:
-		m.err = msg
-		return m, nil // Don't quit, just store the error and let the View function display it
-	case yamlMsg: // New case
-		m.yamlContent = msg.yaml
-		m.view = viewYAML
-		return m, nil
-	case dashboardMsg: // New case for dashboard metrics
-		m.clusterCPUUsage = msg.clusterCPUUsage
-		m.clusterMemoryUsage = msg.clusterMemoryUsage
-		m.topPodsByCPU = msg.topPodsByCPU
-		m.topPodsByMemory = msg.topPodsByMemory
-		m.topNodesByCPU = msg.topNodesByCPU
-		m.topNodesByMemory = msg.topNodesByMemory
-		return m, doTick()
-	case tea.KeyMsg:
-		if m.view == viewConfirmDelete {
-			switch msg.String() {
-			case "y", "Y":
-				pod := m.pods[m.cursor]
-				return m, deletePod(m.clientset, pod.Namespace, pod.Name)
-			case "n", "N", "esc":
-				m.view = viewDetails
+		return pvcsMsg{pvcs: pvcs.Items, namespace: namespace}
+	}
+}
+
+// searchedKinds are the kinds the ctrl+p search finds, in result order.
+var searchedKinds = []struct {
+	kind string
+	gvr  schema.GroupVersionResource
+}{
+	{"Pod", v1.SchemeGroupVersion.WithResource("pods")},
+	{"Deployment", appsv1.SchemeGroupVersion.WithResource("deployments")},
+	{"StatefulSet", appsv1.SchemeGroupVersion.WithResource("statefulsets")},
+	{"DaemonSet", appsv1.SchemeGroupVersion.WithResource("daemonsets")},
+	{"CronJob", batchv1.SchemeGroupVersion.WithResource("cronjobs")},
+	{"Service", v1.SchemeGroupVersion.WithResource("services")},
+	{"ConfigMap", v1.SchemeGroupVersion.WithResource("configmaps")},
+}
+
+// getSearchIndex lists the names of each of searchedKinds in namespace for
+// the ctrl+p search. Only object metadata is listed, so opening the search
+// never downloads pod specs or ConfigMap data. Kinds the user can't list are
+// left out instead of failing the whole search.
+func getSearchIndex(metadataClient metadata.Interface, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		// One slice per kind, so results keep a stable kind order
+		lists := make([][]resourceRef, len(searchedKinds))
+		errs := make([]error, len(searchedKinds))
+		var g errgroup.Group
+		for i, k := range searchedKinds {
+			g.Go(func() error {
+				list, err := metadataClient.Resource(k.gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+				if err != nil {
+					errs[i] = err
+					return nil
+				}
+				for _, item := range list.Items {
+					lists[i] = append(lists[i], resourceRef{k.kind, item.Namespace, item.Name})
+				}
+				return nil
+			})
+		}
+		g.Wait()
+		items := []resourceRef{} // Non-nil, so the view stops showing "Loading..."
+		failed := 0
+		for i, l := range lists {
+			if errs[i] != nil {
+				logger.Warn("search index skips a kind", slog.String("kind", searchedKinds[i].kind), slog.Any("error", errs[i]))
+				failed++
 			}
-			return m, nil
+			items = append(items, l...)
 		}
-		if m.view == viewScaling {
-			switch msg.String() {
-			case "enter":
-				replicaCount, err := strconv.Atoi(m.textInput.Value())
-				if err == nil {
-					d := m.deployments[m.cursor]
-					return m, scaleDeployment(m.clientset, d.Namespace, d.Name, int32(replicaCount))
-				}
-			case "esc":
-				m.view = viewDetails
-				m.textInput.Reset()
+		if failed == len(searchedKinds) {
+			return searchIndexMsg{items: items, err: errs[0]}
+		}
+		return searchIndexMsg{items: items}
+	}
+}
+
+// fuzzyScore reports whether every character of query appears in name in
+// order, ignoring case. Lower scores are better matches: a match counts the
+// gaps between matched characters plus how late the first one appears, so
+// "ngx" ranks "nginx" above "nginx-gateway-exporter".
+func fuzzyScore(query, name string) (int, bool) {
+	query, name = strings.ToLower(query), strings.ToLower(name)
+	score, last := 0, -1
+	for _, c := range query {
+		i := strings.IndexRune(name[last+1:], c)
+		if i < 0 {
+			return 0, false
+		}
+		if last < 0 {
+			score += i
+		} else {
+			score += i * 2 // Gaps inside the match cost more than a late start
+		}
+		last += 1 + i
+	}
+	return score + len(name) - len(query), true
+}
+
+// searchResults returns the search items matching the query, best first.
+func (m *model) searchResults() []resourceRef {
+	query := strings.TrimSpace(m.textInput.Value())
+	type scored struct {
+		ref   resourceRef
+		score int
+	}
+	var matches []scored
+	for _, ref := range m.searchItems {
+		if score, ok := fuzzyScore(query, ref.name); ok {
+			matches = append(matches, scored{ref, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].score < matches[j].score })
+	results := make([]resourceRef, len(matches))
+	for i, s := range matches {
+		results[i] = s.ref
+	}
+	return results
+}
+
+// refreshPVCUsage rereads PVC usage from the kubelets once it's older than
+// pvcUsageInterval or was read for another namespace, and is nil otherwise.
+func (m *model) refreshPVCUsage() tea.Cmd {
+	stamp := m.pvcUsageStamp
+	if !stamp.at.IsZero() && time.Since(stamp.at) < pvcUsageInterval && stamp.namespace == m.selectedNamespace {
+		return nil
+	}
+	m.pvcUsageStamp = fetchStamp{namespace: m.selectedNamespace, at: time.Now()}
+	return getPVCUsage(m.clientset, m.selectedNamespace)
+}
+
+// volumeStats is the filesystem usage of a mounted volume.
+type volumeStats struct {
+	used, capacity int64
+}
+
+// getPVCUsage reads volume usage from the kubelet stats summary of every node
+// running a pod that mounts a PVC in namespace. Only mounted PVCs have stats,
+// and nodes whose kubelet can't be reached are skipped, like missing metrics.
+func getPVCUsage(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		return pvcUsageMsg{readPVCUsage(ctx, clientset, namespace)}
+	}
+}
+
+func readPVCUsage(ctx context.Context, clientset *kubernetes.Clientset, namespace string) map[string]volumeStats {
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil
+	}
+	nodes := make(map[string]bool)
+	for _, pod := range pods.Items {
+		for _, vol := range pod.Spec.Volumes {
+			if vol.PersistentVolumeClaim != nil && pod.Spec.NodeName != "" && pod.Status.Phase == v1.PodRunning {
+				nodes[pod.Spec.NodeName] = true
+			}
+		}
+	}
+
+	var mu sync.Mutex
+	usage := make(map[string]volumeStats)
+	var g errgroup.Group
+	g.SetLimit(5)
+	for node := range nodes {
+		g.Go(func() error {
+			data, err := clientset.CoreV1().RESTClient().Get().Resource("nodes").Name(node).SubResource("proxy").Suffix("stats/summary").DoRaw(ctx)
+			if err != nil {
+				return nil
+			}
+			stats, err := parseVolumeStats(data)
+			if err != nil {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for k, v := range stats {
+				usage[k] = v
+			}
+			return nil
+		})
+	}
+	g.Wait()
+	return usage
+}
+
+// parseVolumeStats extracts PVC usage, by namespace/name, from a kubelet
+// /stats/summary response.
+func parseVolumeStats(data []byte) (map[string]volumeStats, error) {
+	var summary struct {
+		Pods []struct {
+			Volume []struct {
+				UsedBytes     *int64 `json:"usedBytes"`
+				CapacityBytes *int64 `json:"capacityBytes"`
+				PVCRef        *struct {
+					Name      string `json:"name"`
+					Namespace string `json:"namespace"`
+				} `json:"pvcRef"`
+			} `json:"volume"`
+		} `json:"pods"`
+	}
+	if err := stdjson.Unmarshal(data, &summary); err != nil {
+		return nil, err
+	}
+	stats := make(map[string]volumeStats)
+	for _, pod := range summary.Pods {
+		for _, vol := range pod.Volume {
+			if vol.PVCRef == nil || vol.UsedBytes == nil || vol.CapacityBytes == nil {
+				continue
+			}
+			stats[vol.PVCRef.Namespace+"/"+vol.PVCRef.Name] = volumeStats{used: *vol.UsedBytes, capacity: *vol.CapacityBytes}
+		}
+	}
+	return stats, nil
+}
+
+func getPVs(clientset *kubernetes.Clientset) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		pvs, err := clientset.CoreV1().PersistentVolumes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewPVs, "", err)
+		}
+		return pvsMsg{pvs.Items}
+	}
+}
+
+// getController follows a pod's controller owner references up to the
+// top-level controller, e.g. Pod -> ReplicaSet -> Deployment or
+// Pod -> Job -> CronJob.
+func getController(clientset kubernetes.Interface, pod v1.Pod) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		ref := metav1.GetControllerOf(&pod)
+		if ref == nil {
+			return errMsg{fmt.Errorf("pod %s has no controller", pod.Name)}
+		}
+		for {
+			var owner metav1.Object
+			var err error
+			switch ref.Kind {
+			case "ReplicaSet":
+				owner, err = clientset.AppsV1().ReplicaSets(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+			case "Job":
+				owner, err = clientset.BatchV1().Jobs(pod.Namespace).Get(ctx, ref.Name, metav1.GetOptions{})
 			default:
-				m.textInput, cmd = m.textInput.Update(msg)
-				cmds = append(cmds, cmd)
+				return controllerMsg{kind: ref.Kind, namespace: pod.Namespace, name: ref.Name}
 			}
-			return m, tea.Batch(cmds...)
+			if err != nil {
+				return errMsg{err}
+			}
+			next := metav1.GetControllerOf(owner)
+			if next == nil {
+				return controllerMsg{kind: ref.Kind, namespace: pod.Namespace, name: ref.Name}
+			}
+			ref = next
+		}
+	}
+}
+
+func getDeployments(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewDeployments, namespace, err)
+		}
+		return deploymentsMsg{deployments: deployments.Items, namespace: namespace}
+	}
+}
+
+func getStatefulSets(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		statefulsets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewStatefulSets, namespace, err)
+		}
+		return statefulsetsMsg{statefulsets: statefulsets.Items, namespace: namespace}
+	}
+}
+
+func getDaemonSets(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		daemonsets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewDaemonSets, namespace, err)
+		}
+		return daemonsetsMsg{daemonsets: daemonsets.Items, namespace: namespace}
+	}
+}
+
+func getServices(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		services, err := clientset.CoreV1().Services(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewServices, namespace, err)
+		}
+		return servicesMsg{services: services.Items, namespace: namespace}
+	}
+}
+
+func getNetworkPolicies(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		policies, err := clientset.NetworkingV1().NetworkPolicies(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewNetworkPolicies, namespace, err)
+		}
+		return networkPoliciesMsg{policies: policies.Items, namespace: namespace}
+	}
+}
+
+func getEvents(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		events, err := clientset.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewEvents, namespace, err)
+		}
+		sort.Slice(events.Items, func(i, j int) bool {
+			return events.Items[i].LastTimestamp.Time.After(events.Items[j].LastTimestamp.Time)
+		})
+		return eventsMsg{events: events.Items, resourceVersion: events.ResourceVersion}
+	}
+}
+
+func getRoles(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		roles, err := clientset.RbacV1().Roles(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewRoles, namespace, err)
+		}
+		return rolesMsg{roles: roles.Items, namespace: namespace}
+	}
+}
+
+func getRoleBindings(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		bindings, err := clientset.RbacV1().RoleBindings(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewRoleBindings, namespace, err)
+		}
+		return roleBindingsMsg{bindings: bindings.Items, namespace: namespace}
+	}
+}
+
+func getResourceQuotas(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		quotas, err := clientset.CoreV1().ResourceQuotas(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewResourceQuotas, namespace, err)
+		}
+		return resourceQuotasMsg{quotas: quotas.Items, namespace: namespace}
+	}
+}
+
+func getLimitRanges(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		limitRanges, err := clientset.CoreV1().LimitRanges(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewLimitRanges, namespace, err)
+		}
+		return limitRangesMsg{limitRanges: limitRanges.Items, namespace: namespace}
+	}
+}
+
+func getCronJobs(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		cronJobs, err := clientset.BatchV1().CronJobs(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewCronJobs, namespace, err)
+		}
+		return cronJobsMsg{cronJobs: cronJobs.Items, namespace: namespace}
+	}
+}
+
+// setCronJobSuspend pauses or resumes a CronJob. Jobs it already started keep
+// running; suspending only stops new ones from being scheduled.
+func setCronJobSuspend(clientset kubernetes.Interface, namespace, name string, suspend bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		cronJob, err := clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return errMsg{err}
+		}
+		cronJob.Spec.Suspend = &suspend
+		if _, err := clientset.BatchV1().CronJobs(namespace).Update(ctx, cronJob, metav1.UpdateOptions{}); err != nil {
+			return errMsg{err}
+		}
+		return cronJobSuspendedMsg{}
+	}
+}
+
+func getPDBs(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		pdbs, err := clientset.PolicyV1().PodDisruptionBudgets(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewPDBs, namespace, err)
+		}
+		return pdbsMsg{pdbs: pdbs.Items, namespace: namespace}
+	}
+}
+
+func getEndpointSlices(clientset *kubernetes.Clientset, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		slices, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewEndpointSlices, namespace, err)
+		}
+		return endpointSlicesMsg{slices: slices.Items, namespace: namespace}
+	}
+}
+
+// getDaemonSetCoverage lists the nodes and the DaemonSet's pods to show which
+// nodes it runs on, and why it skips the others.
+func getDaemonSetCoverage(clientset kubernetes.Interface, d appsv1.DaemonSet) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return errMsg{fmt.Errorf("listing nodes for DaemonSet coverage: %w", err)}
+		}
+		pods, err := clientset.CoreV1().Pods(d.Namespace).List(ctx, metav1.ListOptions{LabelSelector: metav1.FormatLabelSelector(d.Spec.Selector)})
+		if err != nil {
+			return errMsg{err}
+		}
+		return daemonSetCoverageMsg{namespace: d.Namespace, name: d.Name, rows: buildDaemonSetCoverage(d, nodes.Items, pods.Items)}
+	}
+}
+
+// getNodeEvents lists the events whose involved object is the given node.
+func getNodeEvents(clientset *kubernetes.Clientset, nodeName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		selector := fmt.Sprintf("involvedObject.kind=Node,involvedObject.name=%s", nodeName)
+		events, err := clientset.CoreV1().Events("").List(ctx, metav1.ListOptions{FieldSelector: selector})
+		if err != nil {
+			return errMsg{err}
+		}
+		sort.Slice(events.Items, func(i, j int) bool {
+			return events.Items[i].LastTimestamp.Time.After(events.Items[j].LastTimestamp.Time)
+		})
+		return nodeEventsMsg{node: nodeName, events: events.Items}
+	}
+}
+
+// watchEvents starts a watch on events in namespace from resourceVersion onwards.
+func watchEvents(clientset *kubernetes.Clientset, namespace, resourceVersion string) tea.Cmd {
+	return func() tea.Msg {
+		w, err := clientset.CoreV1().Events(namespace).Watch(rootCtx, metav1.ListOptions{ResourceVersion: resourceVersion})
+		if err != nil {
+			return errMsg{err}
+		}
+		return eventWatchStartedMsg{w}
+	}
+}
+
+// nextWatchEvent waits for the next event from the watcher.
+func nextWatchEvent(w watch.Interface) tea.Cmd {
+	return func() tea.Msg {
+		e, ok := <-w.ResultChan()
+		if !ok {
+			return eventWatchClosedMsg{w}
+		}
+		return eventWatchMsg{watcher: w, event: e}
+	}
+}
+
+// applyEventWatch updates events for a single watch notification. Added and
+// modified events move to the front; the list is capped at maxWatchedEvents.
+func applyEventWatch(events []v1.Event, e watch.Event) []v1.Event {
+	ev, ok := e.Object.(*v1.Event)
+	if !ok {
+		return events
+	}
+	kept := make([]v1.Event, 0, len(events)+1)
+	if e.Type == watch.Added || e.Type == watch.Modified {
+		kept = append(kept, *ev)
+	}
+	for _, existing := range events {
+		if existing.UID != ev.UID {
+			kept = append(kept, existing)
+		}
+	}
+	if len(kept) > maxWatchedEvents {
+		kept = kept[:maxWatchedEvents]
+	}
+	return kept
+}
+
+func getNamespaces(clientset *kubernetes.Clientset) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		ns, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewNamespaces, "", err)
+		}
+		return namespacesMsg{ns.Items}
+	}
+}
+
+// crdInfo is the subset of a CustomResourceDefinition needed to list its resources.
+type crdInfo struct {
+	name       string
+	kind       string
+	gvr        schema.GroupVersionResource
+	namespaced bool
+}
+
+var crdGVR = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// getCRDs lists installed CustomResourceDefinitions through the dynamic client.
+func getCRDs(dynamicClient dynamic.Interface) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		list, err := dynamicClient.Resource(crdGVR).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return listError(viewCRDs, "", err)
+		}
+		var crds []crdInfo
+		for _, item := range list.Items {
+			if crd, ok := parseCRD(item); ok {
+				crds = append(crds, crd)
+			}
+		}
+		sort.Slice(crds, func(i, j int) bool { return crds[i].name < crds[j].name })
+		return crdsMsg{crds}
+	}
+}
+
+// parseCRD extracts the group, storage version and plural resource name of a CRD.
+func parseCRD(obj unstructured.Unstructured) (crdInfo, bool) {
+	group, _, _ := unstructured.NestedString(obj.Object, "spec", "group")
+	plural, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "plural")
+	kind, _, _ := unstructured.NestedString(obj.Object, "spec", "names", "kind")
+	scope, _, _ := unstructured.NestedString(obj.Object, "spec", "scope")
+	versions, _, _ := unstructured.NestedSlice(obj.Object, "spec", "versions")
+
+	var version string
+	for _, v := range versions {
+		vm, ok := v.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := vm["name"].(string)
+		if storage, _ := vm["storage"].(bool); storage || version == "" {
+			version = name
+		}
+	}
+	if group == "" || plural == "" || version == "" {
+		return crdInfo{}, false
+	}
+	return crdInfo{
+		name:       obj.GetName(),
+		kind:       kind,
+		gvr:        schema.GroupVersionResource{Group: group, Version: version, Resource: plural},
+		namespaced: scope == "Namespaced",
+	}, true
+}
+
+func getCustomResources(dynamicClient dynamic.Interface, crd crdInfo, namespace string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var list *unstructured.UnstructuredList
+		var err error
+		if crd.namespaced {
+			list, err = dynamicClient.Resource(crd.gvr).Namespace(namespace).List(ctx, metav1.ListOptions{})
+		} else {
+			list, err = dynamicClient.Resource(crd.gvr).List(ctx, metav1.ListOptions{})
+		}
+		if err != nil {
+			return listError(viewCustomResources, namespace, err)
+		}
+		return customResourcesMsg{resources: list.Items, namespace: namespace}
+	}
+}
+
+// getCustomResourceYAML fetches a custom resource and returns its YAML representation.
+func getCustomResourceYAML(dynamicClient dynamic.Interface, crd crdInfo, namespace, name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var obj *unstructured.Unstructured
+		var err error
+		if crd.namespaced {
+			obj, err = dynamicClient.Resource(crd.gvr).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+		} else {
+			obj, err = dynamicClient.Resource(crd.gvr).Get(ctx, name, metav1.GetOptions{})
+		}
+		if err != nil {
+			return errMsg{err}
+		}
+		b, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return errMsg{err}
+		}
+		return yamlMsg{yaml: string(b), obj: obj}
+	}
+}
+
+// resourceRef identifies a built-in resource for getResourceObject.
+type resourceRef struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (r resourceRef) String() string {
+	if r.namespace == "" {
+		return fmt.Sprintf("%s %s", r.kind, r.name)
+	}
+	return fmt.Sprintf("%s %s/%s", r.kind, r.namespace, r.name)
+}
+
+// selectedResource returns the resource under the cursor in list view v.
+// Custom resources and namespaces aren't covered.
+func (m model) selectedResource(v viewState) (resourceRef, bool) {
+	if m.cursor >= m.listLenOf(v) {
+		return resourceRef{}, false
+	}
+	switch v {
+	case viewNodes:
+		return resourceRef{kind: "Node", name: m.nodes[m.cursor].Name}, true
+	case viewPods:
+		return resourceRef{kind: "Pod", namespace: m.pods[m.cursor].Namespace, name: m.pods[m.cursor].Name}, true
+	case viewPVCs:
+		return resourceRef{kind: "PersistentVolumeClaim", namespace: m.pvcs[m.cursor].Namespace, name: m.pvcs[m.cursor].Name}, true
+	case viewPVs:
+		return resourceRef{kind: "PersistentVolume", name: m.pvs[m.cursor].Name}, true
+	case viewDeployments:
+		return resourceRef{kind: "Deployment", namespace: m.deployments[m.cursor].Namespace, name: m.deployments[m.cursor].Name}, true
+	case viewStatefulSets:
+		return resourceRef{kind: "StatefulSet", namespace: m.statefulsets[m.cursor].Namespace, name: m.statefulsets[m.cursor].Name}, true
+	case viewDaemonSets:
+		return resourceRef{kind: "DaemonSet", namespace: m.daemonsets[m.cursor].Namespace, name: m.daemonsets[m.cursor].Name}, true
+	case viewServices:
+		return resourceRef{kind: "Service", namespace: m.services[m.cursor].Namespace, name: m.services[m.cursor].Name}, true
+	case viewNetworkPolicies:
+		return resourceRef{kind: "NetworkPolicy", namespace: m.netpols[m.cursor].Namespace, name: m.netpols[m.cursor].Name}, true
+	case viewEvents:
+		e := m.selectedEvent()
+		return resourceRef{kind: "Event", namespace: e.Namespace, name: e.Name}, true
+	case viewRoles:
+		return resourceRef{kind: "Role", namespace: m.roles[m.cursor].Namespace, name: m.roles[m.cursor].Name}, true
+	case viewRoleBindings:
+		return resourceRef{kind: "RoleBinding", namespace: m.roleBindings[m.cursor].Namespace, name: m.roleBindings[m.cursor].Name}, true
+	case viewResourceQuotas:
+		return resourceRef{kind: "ResourceQuota", namespace: m.resourceQuotas[m.cursor].Namespace, name: m.resourceQuotas[m.cursor].Name}, true
+	case viewLimitRanges:
+		return resourceRef{kind: "LimitRange", namespace: m.limitRanges[m.cursor].Namespace, name: m.limitRanges[m.cursor].Name}, true
+	case viewPDBs:
+		return resourceRef{kind: "PodDisruptionBudget", namespace: m.pdbs[m.cursor].Namespace, name: m.pdbs[m.cursor].Name}, true
+	case viewCronJobs:
+		return resourceRef{kind: "CronJob", namespace: m.cronJobs[m.cursor].Namespace, name: m.cronJobs[m.cursor].Name}, true
+	case viewEndpointSlices:
+		return resourceRef{kind: "EndpointSlice", namespace: m.endpointSlices[m.cursor].Namespace, name: m.endpointSlices[m.cursor].Name}, true
+	}
+	return resourceRef{}, false
+}
+
+// selectedName returns the name of the row selected in list view v, including
+// the lists selectedResource doesn't cover.
+func (m model) selectedName(v viewState) (string, bool) {
+	switch v {
+	case viewNamespaces:
+		if m.cursor == 0 || m.cursor > len(m.namespaces) {
+			return "", false // The "All Namespaces" row
+		}
+		return m.namespaces[m.cursor-1].Name, true
+	case viewCRDs:
+		if m.cursor < len(m.crds) {
+			return m.crds[m.cursor].name, true
+		}
+	case viewCustomResources:
+		if m.cursor < len(m.customResources) {
+			return m.customResources[m.cursor].GetName(), true
+		}
+	}
+	ref, ok := m.selectedResource(v)
+	return ref.name, ok
+}
+
+// describeTarget returns the kubectl resource type, namespace and name of the
+// row selected in list view v.
+func (m model) describeTarget(v viewState) (resourceRef, bool) {
+	switch v {
+	case viewCRDs:
+		if m.cursor < len(m.crds) {
+			return resourceRef{kind: "CustomResourceDefinition", name: m.crds[m.cursor].name}, true
+		}
+		return resourceRef{}, false
+	case viewCustomResources:
+		if m.cursor < len(m.customResources) {
+			cr := m.customResources[m.cursor]
+			return resourceRef{kind: m.selectedCRD.name, namespace: cr.GetNamespace(), name: cr.GetName()}, true
+		}
+		return resourceRef{}, false
+	}
+	return m.selectedResource(v)
+}
+
+// kubectlDescribe runs kubectl describe for ref against the model's cluster
+// and pages the output with $PAGER, or less. Arguments are passed to the shell
+// positionally, so names are never interpreted by it.
+func (m model) kubectlDescribe(ref resourceRef) *exec.Cmd {
+	args := []string{"-c", `kubectl "$@" 2>&1 | ${PAGER:-less}`, "kubectl"}
+	if m.kubeconfig != "" {
+		args = append(args, "--kubeconfig", m.kubeconfig)
+	}
+	if m.kubeContext != "" {
+		args = append(args, "--context", m.kubeContext)
+	}
+	args = append(args, "describe", ref.kind, ref.name)
+	if ref.namespace != "" {
+		args = append(args, "--namespace", ref.namespace)
+	}
+	return exec.Command("sh", args...)
+}
+
+// parsePortForward reads "local[:port]" typed for svc. The service port may be
+// given by number or name and can be left out when the service has only one.
+func parsePortForward(input string, svc v1.Service) (int, int32, error) {
+	localText, portText, hasPort := strings.Cut(strings.TrimSpace(input), ":")
+	local, err := strconv.Atoi(localText)
+	if err != nil || local < 1 || local > 65535 {
+		return 0, 0, fmt.Errorf("invalid local port %q", localText)
+	}
+	if len(svc.Spec.Ports) == 0 {
+		return 0, 0, fmt.Errorf("service %s exposes no ports", svc.Name)
+	}
+	if !hasPort {
+		if len(svc.Spec.Ports) > 1 {
+			return 0, 0, fmt.Errorf("service %s has %d ports, use local:port to pick one", svc.Name, len(svc.Spec.Ports))
+		}
+		return local, svc.Spec.Ports[0].Port, nil
+	}
+	for _, p := range svc.Spec.Ports {
+		if portText == p.Name || portText == strconv.Itoa(int(p.Port)) {
+			return local, p.Port, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("service %s has no port %q", svc.Name, portText)
+}
+
+// suggestedLocalPort moves privileged ports out of the way, e.g. 80 to 8080,
+// so the suggestion can be bound without root.
+func suggestedLocalPort(port int32) int32 {
+	if port < 1024 {
+		return port + 8000
+	}
+	return port
+}
+
+// kubectlPortForward forwards local to port of svc until interrupted. kubectl
+// picks a ready pod behind the service and follows the port to its target
+// port. kubectl's stderr is also copied to stderr so a failure can be shown
+// once the UI is back.
+func (m model) kubectlPortForward(svc v1.Service, local int, port int32, stderr io.Writer) *exec.Cmd {
+	args := []string{"-c", `echo "Forwarding localhost:$1 to service $2, press ctrl+c to stop"; shift 2; exec kubectl "$@"`, "kubectl",
+		strconv.Itoa(local), fmt.Sprintf("%s/%s:%d", svc.Namespace, svc.Name, port)}
+	if m.kubeconfig != "" {
+		args = append(args, "--kubeconfig", m.kubeconfig)
+	}
+	if m.kubeContext != "" {
+		args = append(args, "--context", m.kubeContext)
+	}
+	args = append(args, "port-forward", "svc/"+svc.Name, fmt.Sprintf("%d:%d", local, port), "--namespace", svc.Namespace)
+	cmd := exec.Command("sh", args...)
+	cmd.Stderr = io.MultiWriter(os.Stderr, stderr)
+	return cmd
+}
+
+// copyToClipboard puts text on the system clipboard. Without a local
+// clipboard tool (e.g. over SSH) it asks the terminal to copy through OSC 52
+// instead, which most modern terminals support.
+func copyToClipboard(text string) tea.Cmd {
+	return func() tea.Msg {
+		if err := clipboard.WriteAll(text); err != nil {
+			logger.Debug("no system clipboard, copying through the terminal", slog.Any("error", err))
+			return copiedMsg{text: text, viaTerminal: true}
+		}
+		return copiedMsg{text: text}
+	}
+}
+
+// terminalSeqHold is how long a terminal escape sequence stays in the frames,
+// comfortably longer than one frame so the renderer writes it.
+const terminalSeqHold = 200 * time.Millisecond
+
+// writeToTerminal has the next frames carry seq to the terminal.
+func (m *model) writeToTerminal(seq string) tea.Cmd {
+	m.terminalSeq += seq
+	return tea.Tick(terminalSeqHold, func(time.Time) tea.Msg { return clearTerminalSeqMsg{} })
+}
+
+// detailField is a "Key:<tab>value" line of the details, which ctrl+y copies.
+type detailField struct {
+	line  int
+	value string
+}
+
+// detailFields returns the fields of details, in order.
+func detailFields(details string) []detailField {
+	var fields []detailField
+	for i, line := range strings.Split(ansi.Strip(details), "\n") {
+		if _, value, ok := strings.Cut(strings.TrimSpace(line), ":\t"); ok && strings.TrimSpace(value) != "" {
+			fields = append(fields, detailField{line: i, value: strings.TrimSpace(value)})
+		}
+	}
+	return fields
+}
+
+// detailsView renders the details with the field ctrl+y copies highlighted.
+func (m *model) detailsView() string {
+	fields := detailFields(m.details)
+	if m.detailsField >= len(fields) {
+		return m.details
+	}
+	lines := strings.Split(m.details, "\n")
+	l := fields[m.detailsField].line
+	lines[l] = m.styles.SelectedRow.Render(ansi.Strip(lines[l]))
+	return strings.Join(lines, "\n")
+}
+
+// moveDetailsField moves the details field cursor by delta, scrolling the
+// field into view.
+func (m *model) moveDetailsField(delta int) {
+	fields := detailFields(m.details)
+	if len(fields) == 0 {
+		return
+	}
+	m.detailsField = min(max(m.detailsField+delta, 0), len(fields)-1)
+	offset := m.viewport.YOffset
+	m.setViewportContent(m.detailsView())
+	m.viewport.SetYOffset(offset)
+	// Lines before the field may wrap, so count rows the way the viewport does
+	lines := strings.Split(m.details, "\n")
+	row := lipgloss.Height(wrapText(strings.Join(lines[:fields[m.detailsField].line+1], "\n"), m.viewport.Width)) - 1
+	if row < m.viewport.YOffset {
+		m.viewport.SetYOffset(row)
+	} else if m.viewport.Height > 0 && row >= m.viewport.YOffset+m.viewport.Height {
+		m.viewport.SetYOffset(row - m.viewport.Height + 1)
+	}
+}
+
+// getResourceObject fetches a built-in resource by kind.
+func getResourceObject(ctx context.Context, clientset kubernetes.Interface, namespace, name, kind string) (runtime.Object, error) {
+	switch kind {
+	case "Pod":
+		return clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "Deployment":
+		return clientset.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "StatefulSet":
+		return clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "DaemonSet":
+		return clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "Service":
+		return clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "ConfigMap":
+		return clientset.CoreV1().ConfigMaps(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "PersistentVolumeClaim":
+		return clientset.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "PersistentVolume":
+		return clientset.CoreV1().PersistentVolumes().Get(ctx, name, metav1.GetOptions{})
+	case "NetworkPolicy":
+		return clientset.NetworkingV1().NetworkPolicies(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "Node":
+		return clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	case "Event":
+		return clientset.CoreV1().Events(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "Namespace":
+		return clientset.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+	case "Role":
+		return clientset.RbacV1().Roles(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "RoleBinding":
+		return clientset.RbacV1().RoleBindings(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "ResourceQuota":
+		return clientset.CoreV1().ResourceQuotas(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "LimitRange":
+		return clientset.CoreV1().LimitRanges(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "PodDisruptionBudget":
+		return clientset.PolicyV1().PodDisruptionBudgets(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "CronJob":
+		return clientset.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+	case "EndpointSlice":
+		return clientset.DiscoveryV1().EndpointSlices(namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return nil, fmt.Errorf("unsupported resource kind: %s", kind)
+	}
+}
+
+// getResourceYAML fetches a resource and returns its YAML representation.
+func getResourceYAML(clientset *kubernetes.Clientset, namespace, name, kind string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		obj, err := getResourceObject(ctx, clientset, namespace, name, kind)
+		if err != nil {
+			return errMsg{err}
+		}
+		out, err := encodeYAML(obj)
+		if err != nil {
+			return errMsg{err}
+		}
+		return yamlMsg{yaml: out, obj: obj}
+	}
+}
+
+// getResourceDiff fetches two resources of the same kind and returns a
+// unified diff of their YAML. Server-managed metadata that always differs
+// (uid, resourceVersion, managedFields, ...) is dropped first.
+func getResourceDiff(clientset *kubernetes.Clientset, a, b resourceRef) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var texts [2]string
+		for i, ref := range []resourceRef{a, b} {
+			obj, err := getResourceObject(ctx, clientset, ref.namespace, ref.name, ref.kind)
+			if err != nil {
+				return errMsg{err}
+			}
+			stripServerMetadata(obj)
+			if texts[i], err = encodeYAML(obj); err != nil {
+				return errMsg{err}
+			}
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(texts[0]),
+			B:        difflib.SplitLines(texts[1]),
+			FromFile: a.String(),
+			ToFile:   b.String(),
+			Context:  3,
+		})
+		if err != nil {
+			return errMsg{err}
+		}
+		if diff == "" {
+			diff = "No differences.\n"
+		}
+		return diffMsg{title: fmt.Sprintf("%s vs %s", a, b), diff: diff}
+	}
+}
+
+func stripServerMetadata(obj runtime.Object) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return
+	}
+	accessor.SetManagedFields(nil)
+	accessor.SetResourceVersion("")
+	accessor.SetUID("")
+	accessor.SetGeneration(0)
+	accessor.SetCreationTimestamp(metav1.Time{})
+}
+
+// colorDiff styles added, removed and hunk header lines of a unified diff.
+func (m *model) colorDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			lines[i] = m.styles.HeaderText.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = m.styles.Success.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = m.styles.Error.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = m.styles.Muted.Render(line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func encodeYAML(obj runtime.Object) (string, error) {
+	s := json.NewYAMLSerializer(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme)
+	var b bytes.Buffer
+	if err := s.Encode(obj, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// encodeJSON renders obj as indented JSON with the same scheme the YAML view uses.
+func encodeJSON(obj runtime.Object) (string, error) {
+	s := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme.Scheme, scheme.Scheme, json.SerializerOptions{Pretty: true})
+	var b bytes.Buffer
+	if err := s.Encode(obj, &b); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// getDashboardMetrics fetches and aggregates resource utilization metrics. With a
+// non-empty namespace, usage and top pods only cover pods in that namespace,
+// measured against the cluster's total capacity.
+func getDashboardMetrics(clientset kubernetes.Interface, metricsClientset metrics.Interface, namespace string, topN int) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		// The four lists are independent, so fetch them concurrently; the
+		// dashboard then takes as long as the slowest call instead of their sum.
+		var (
+			nodes           *v1.NodeList
+			nodeMetricsList *v1beta1.NodeMetricsList
+			pods            *v1.PodList
+			podMetricsList  *v1beta1.PodMetricsList
+		)
+		g, ctx := errgroup.WithContext(ctx)
+		g.Go(func() (err error) {
+			nodes, err = clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			return err
+		})
+		g.Go(func() (err error) {
+			nodeMetricsList, err = metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+			return err
+		})
+		g.Go(func() (err error) {
+			// "" lists all namespaces
+			pods, err = clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+			return err
+		})
+		g.Go(func() (err error) {
+			podMetricsList, err = metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+			return err
+		})
+		if err := g.Wait(); err != nil {
+			return errMsg{refreshError{err}}
+		}
+		return aggregateDashboard(nodes.Items, nodeMetricsList.Items, pods.Items, podMetricsList.Items, namespace, topN)
+	}
+}
+
+// serverVersion is Discovery().ServerVersion() bound to ctx, so -timeout
+// applies to it like to every other call. Clients without a REST client, like
+// the fake one in tests, fall back to the unbound call.
+func serverVersion(ctx context.Context, d discovery.DiscoveryInterface) (*version.Info, error) {
+	rc := d.RESTClient()
+	if rc == nil {
+		return d.ServerVersion()
+	}
+	body, err := rc.Get().AbsPath("/version").Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	var info version.Info
+	if err := stdjson.Unmarshal(body, &info); err != nil {
+		return nil, fmt.Errorf("unable to parse the server version: %w", err)
+	}
+	return &info, nil
+}
+
+// getClusterHealth fetches the server version and the health of the control
+// plane. Clusters that run it as pods (kubeadm, k3s with embedded static pods)
+// label them tier=control-plane; otherwise the deprecated but still served
+// ComponentStatuses are used. Managed clusters usually hide both.
+func getClusterHealth(clientset kubernetes.Interface) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := apiContext()
+		defer cancel()
+		var msg clusterHealthMsg
+		if info, err := serverVersion(ctx, clientset.Discovery()); err != nil {
+			msg.version = fmt.Sprintf("unknown (%v)", err)
+		} else {
+			msg.version = fmt.Sprintf("%s (%s)", info.GitVersion, info.Platform)
+		}
+
+		pods, err := clientset.CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{LabelSelector: "tier=control-plane"})
+		if err == nil && len(pods.Items) > 0 {
+			msg.source = "control-plane pods"
+			for _, pod := range pods.Items {
+				name := pod.Labels["component"]
+				if name == "" {
+					name = pod.Name
+				}
+				msg.components = append(msg.components, controlPlaneComponent{
+					name:    name,
+					healthy: isPodReady(pod),
+					detail:  fmt.Sprintf("%s on %s", getPodStatus(pod), pod.Spec.NodeName),
+				})
+			}
+		} else if statuses, err := clientset.CoreV1().ComponentStatuses().List(ctx, metav1.ListOptions{}); err == nil && len(statuses.Items) > 0 {
+			msg.source = "ComponentStatuses"
+			for _, cs := range statuses.Items {
+				c := controlPlaneComponent{name: cs.Name, detail: "no health reported"}
+				for _, cond := range cs.Conditions {
+					if cond.Type == v1.ComponentHealthy {
+						c.healthy = cond.Status == v1.ConditionTrue
+						c.detail = strings.TrimSpace(cond.Message + " " + cond.Error)
+					}
+				}
+				msg.components = append(msg.components, c)
+			}
+		}
+		sort.Slice(msg.components, func(i, j int) bool { return msg.components[i].name < msg.components[j].name })
+		return msg
+	}
+}
+
+// aggregateDashboard computes the dashboard totals and top-N lists. When
+// namespace is set, usage is the sum over that namespace's pods while capacity
+// stays cluster-wide.
+func aggregateDashboard(nodes []v1.Node, nodeMetrics []v1beta1.NodeMetrics, pods []v1.Pod, podMetrics []v1beta1.PodMetrics, namespace string, topN int) dashboardMsg {
+	var totalCPUCapacity, totalMemoryCapacity resource.Quantity
+	var totalCPUUsage, totalMemoryUsage resource.Quantity
+
+	nodeMetricsMap := make(map[string]v1beta1.NodeMetrics)
+	for _, nm := range nodeMetrics {
+		nodeMetricsMap[nm.Name] = nm
+	}
+
+	// Aggregate Node Capacity and Usage
+	for _, node := range nodes {
+		totalCPUCapacity.Add(*node.Status.Capacity.Cpu())
+		totalMemoryCapacity.Add(*node.Status.Capacity.Memory())
+		if nm, ok := nodeMetricsMap[node.Name]; ok {
+			totalCPUUsage.Add(*nm.Usage.Cpu())
+			totalMemoryUsage.Add(*nm.Usage.Memory())
+		}
+	}
+
+	podMetricsMap := make(map[string]v1beta1.PodMetrics)
+	for _, pm := range podMetrics {
+		podMetricsMap[pm.Name] = pm
+	}
+
+	// Scope aggregate usage to the namespace's pods
+	if namespace != "" {
+		totalCPUUsage, totalMemoryUsage = resource.Quantity{}, resource.Quantity{}
+		for _, pm := range podMetrics {
+			totalCPUUsage.Add(*totalPodCPU(pm))
+			totalMemoryUsage.Add(*totalPodMemory(pm))
+		}
+	}
+
+	// Requests of pods scheduled on a node and not yet finished, so the gap
+	// to usage shows how over-committed (or idle) the cluster is
+	var totalCPURequests, totalMemoryRequests resource.Quantity
+	for _, alloc := range groupPodsByNode(pods) {
+		totalCPURequests.Add(alloc.cpuRequests)
+		totalMemoryRequests.Add(alloc.memoryRequests)
+	}
+
+	// Prepare for sorting top pods/nodes
+	type podWithMetrics struct {
+		v1.Pod
+		CPUUsage    *resource.Quantity
+		MemoryUsage *resource.Quantity
+	}
+	type nodeWithMetrics struct {
+		v1.Node
+		CPUUsage    *resource.Quantity
+		MemoryUsage *resource.Quantity
+	}
+
+	var podsWithMetrics []podWithMetrics
+	for _, pod := range pods {
+		if pm, ok := podMetricsMap[pod.Name]; ok {
+			podsWithMetrics = append(podsWithMetrics, podWithMetrics{
+				Pod:         pod,
+				CPUUsage:    totalPodCPU(pm),
+				MemoryUsage: totalPodMemory(pm),
+			})
+		}
+	}
+
+	var nodesWithMetrics []nodeWithMetrics
+	for _, node := range nodes {
+		if nm, ok := nodeMetricsMap[node.Name]; ok {
+			nodesWithMetrics = append(nodesWithMetrics, nodeWithMetrics{
+				Node:        node,
+				CPUUsage:    nm.Usage.Cpu(),
+				MemoryUsage: nm.Usage.Memory(),
+			})
+		}
+	}
+
+	// Sort pods by CPU usage
+	podsByCPU := make([]podWithMetrics, len(podsWithMetrics))
+	copy(podsByCPU, podsWithMetrics)
+	sort.Slice(podsByCPU, func(i, j int) bool {
+		return podsByCPU[i].CPUUsage.Cmp(*podsByCPU[j].CPUUsage) > 0
+	})
+
+	// Sort pods by Memory usage
+	podsByMemory := make([]podWithMetrics, len(podsWithMetrics))
+	copy(podsByMemory, podsWithMetrics)
+	sort.Slice(podsByMemory, func(i, j int) bool {
+		return podsByMemory[i].MemoryUsage.Cmp(*podsByMemory[j].MemoryUsage) > 0
+	})
+
+	// Sort nodes by CPU usage
+	nodesByCPU := make([]nodeWithMetrics, len(nodesWithMetrics))
+	copy(nodesByCPU, nodesWithMetrics)
+	sort.Slice(nodesByCPU, func(i, j int) bool {
+		return nodesByCPU[i].CPUUsage.Cmp(*nodesByCPU[j].CPUUsage) > 0
+	})
+
+	// Sort nodes by Memory usage
+	nodesByMemory := make([]nodeWithMetrics, len(nodesWithMetrics))
+	copy(nodesByMemory, nodesWithMetrics)
+	sort.Slice(nodesByMemory, func(i, j int) bool {
+		return nodesByMemory[i].MemoryUsage.Cmp(*nodesByMemory[j].MemoryUsage) > 0
+	})
+
+	// Get top N
+	var topPodsCPU, topPodsMem []v1.Pod
+	for i := 0; i < len(podsByCPU) && i < topN; i++ {
+		topPodsCPU = append(topPodsCPU, podsByCPU[i].Pod)
+	}
+	for i := 0; i < len(podsByMemory) && i < topN; i++ {
+		topPodsMem = append(topPodsMem, podsByMemory[i].Pod)
+	}
+
+	var topNodesCPU, topNodesMem []v1.Node
+	for i := 0; i < len(nodesByCPU) && i < topN; i++ {
+		topNodesCPU = append(topNodesCPU, nodesByCPU[i].Node)
+	}
+	for i := 0; i < len(nodesByMemory) && i < topN; i++ {
+		topNodesMem = append(topNodesMem, nodesByMemory[i].Node)
+	}
+
+	return dashboardMsg{
+		totals: clusterTotals{
+			cpuUsage: totalCPUUsage, cpuRequests: totalCPURequests, cpuCapacity: totalCPUCapacity,
+			memoryUsage: totalMemoryUsage, memoryRequests: totalMemoryRequests, memoryCapacity: totalMemoryCapacity,
+		},
+		cpuPercent:       percentOf(totalCPUUsage.MilliValue(), totalCPUCapacity.MilliValue()),
+		memoryPercent:    percentOf(totalMemoryUsage.Value(), totalMemoryCapacity.Value()),
+		topPodsByCPU:     topPodsCPU,
+		topPodsByMemory:  topPodsMem,
+		topNodesByCPU:    topNodesCPU,
+		topNodesByMemory: topNodesMem,
+	}
+}
+
+func (m model) Init() tea.Cmd {
+	// An immediate tick fetches the starting view, which may have been restored from the state file
+	return func() tea.Msg { return tickMsg{} }
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if len(m.excludedNamespaces) > 0 {
+		msg = m.dropExcludedNamespaces(msg)
+	}
+	if v, ok := fetchedView(msg); ok {
+		namespace, _ := fetchedNamespace(msg)
+		m.markFetched(v, namespace)
+		delete(m.forbidden, v)
+	}
+	updated, cmd := m.update(msg)
+	if um, ok := updated.(model); ok {
+		if v, ok := listedView(msg); ok && um.rowTarget != nil && v == um.view {
+			if err := um.selectRowTarget(); err != nil {
+				updated, errCmd := um.update(errMsg{err})
+				um, cmd = updated.(model), tea.Batch(cmd, errCmd)
+			}
+		}
+		um.scrollToCursor()
+		return um, cmd
+	}
+	return updated, cmd
+}
+
+// dropExcludedNamespaces removes the items of -exclude-ns namespaces from a
+// list fetched across all namespaces, so every view and the cursor only see
+// what's left.
+func (m model) dropExcludedNamespaces(msg tea.Msg) tea.Msg {
+	if namespace, ok := fetchedNamespace(msg); !ok || namespace != "" {
+		return msg // Only lists across all namespaces hold excluded namespaces
+	}
+	ex := m.excludedNamespaces
+	switch msg := msg.(type) {
+	case podsMsg:
+		msg.pods = withoutNamespaces(msg.pods, ex)
+		return msg
+	case pvcsMsg:
+		msg.pvcs = withoutNamespaces(msg.pvcs, ex)
+		return msg
+	case deploymentsMsg:
+		msg.deployments = withoutNamespaces(msg.deployments, ex)
+		return msg
+	case statefulsetsMsg:
+		msg.statefulsets = withoutNamespaces(msg.statefulsets, ex)
+		return msg
+	case daemonsetsMsg:
+		msg.daemonsets = withoutNamespaces(msg.daemonsets, ex)
+		return msg
+	case servicesMsg:
+		msg.services = withoutNamespaces(msg.services, ex)
+		return msg
+	case networkPoliciesMsg:
+		msg.policies = withoutNamespaces(msg.policies, ex)
+		return msg
+	case rolesMsg:
+		msg.roles = withoutNamespaces(msg.roles, ex)
+		return msg
+	case roleBindingsMsg:
+		msg.bindings = withoutNamespaces(msg.bindings, ex)
+		return msg
+	case resourceQuotasMsg:
+		msg.quotas = withoutNamespaces(msg.quotas, ex)
+		return msg
+	case limitRangesMsg:
+		msg.limitRanges = withoutNamespaces(msg.limitRanges, ex)
+		return msg
+	case endpointSlicesMsg:
+		msg.slices = withoutNamespaces(msg.slices, ex)
+		return msg
+	case cronJobsMsg:
+		msg.cronJobs = withoutNamespaces(msg.cronJobs, ex)
+		return msg
+	case customResourcesMsg:
+		msg.resources = withoutNamespaces(msg.resources, ex)
+		return msg
+	case pdbsMsg:
+		msg.pdbs = withoutNamespaces(msg.pdbs, ex)
+		return msg
+	}
+	return msg
+}
+
+// withoutNamespaces returns the items that aren't in one of namespaces.
+func withoutNamespaces[T any, P interface {
+	*T
+	GetNamespace() string
+}](items []T, namespaces []string) []T {
+	kept := make([]T, 0, len(items))
+	for i := range items {
+		if !slices.Contains(namespaces, P(&items[i]).GetNamespace()) {
+			kept = append(kept, items[i])
+		}
+	}
+	return kept
+}
+
+func (m model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var (
+		cmd  tea.Cmd
+		cmds []tea.Cmd
+	)
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		headerHeight := lipgloss.Height(m.headerView())
+		footerHeight := lipgloss.Height(m.footerView())
+		verticalMarginHeight := headerHeight + footerHeight
+
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-verticalMarginHeight)
+			m.viewport.YPosition = headerHeight
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - verticalMarginHeight
+		}
+		m.viewport.SetContent(wrapText(m.viewportContent, m.viewport.Width))
+	case tickMsg:
+		if msg.seq != 0 && msg.seq != m.tickSeq {
+			return m, nil // A later timer took over
+		}
+		switch m.view {
+		case viewNodes:
+			return m, getNodes(m.clientset, m.metricsClientset)
+		case viewNodeMap:
+			return m, getNodeMap(m.clientset, m.metricsClientset)
+		case viewPods:
+			return m, m.fetchPods()
+		case viewPVCs:
+			return m, tea.Batch(getPVCs(m.clientset, m.selectedNamespace), m.refreshPVCUsage())
+		case viewPVs:
+			return m, getPVs(m.clientset)
+		case viewDeployments:
+			return m, getDeployments(m.clientset, m.selectedNamespace)
+		case viewStatefulSets:
+			return m, getStatefulSets(m.clientset, m.selectedNamespace)
+		case viewDaemonSets:
+			return m, getDaemonSets(m.clientset, m.selectedNamespace)
+		case viewServices:
+			return m, getServices(m.clientset, m.selectedNamespace)
+		case viewNetworkPolicies:
+			return m, getNetworkPolicies(m.clientset, m.selectedNamespace)
+		case viewEvents:
+			if m.eventWatch != nil {
+				return m.withTick() // The watch keeps the list current
+			}
+			return m, getEvents(m.clientset, m.selectedNamespace)
+		case viewRoles:
+			return m, getRoles(m.clientset, m.selectedNamespace)
+		case viewRoleBindings:
+			return m, getRoleBindings(m.clientset, m.selectedNamespace)
+		case viewResourceQuotas:
+			return m, getResourceQuotas(m.clientset, m.selectedNamespace)
+		case viewLimitRanges:
+			return m, getLimitRanges(m.clientset, m.selectedNamespace)
+		case viewPDBs:
+			return m, getPDBs(m.clientset, m.selectedNamespace)
+		case viewCronJobs:
+			return m, getCronJobs(m.clientset, m.selectedNamespace)
+		case viewEndpointSlices:
+			return m, getEndpointSlices(m.clientset, m.selectedNamespace)
+		case viewCRDs:
+			return m, getCRDs(m.dynamicClient)
+		case viewCustomResources:
+			return m, getCustomResources(m.dynamicClient, m.selectedCRD, m.selectedNamespace)
+		case viewDashboard:
+			return m, getDashboardMetrics(m.clientset, m.metricsClientset, m.dashboardNamespace(), m.topN)
+		case viewRolloutStatus:
+			if m.rolloutWorkload.name != "" {
+				return m, getRolloutStatus(m.clientset, m.rolloutWorkload)
+			}
+		case viewDetails:
+			if m.detailsSource() == viewNodes {
+				return m, getNodes(m.clientset, m.metricsClientset) // Keeps the pressure panel live
+			}
+		}
+		return m.withTick()
+	case logsMsg:
+		m.logsSource = msg.source
+		m.setViewportContent(msg.logs)
+		m.setView(viewLogs)
+		return m, nil
+	case scaleDownPreviewMsg:
+		if m.view != msg.view {
+			return m, nil // The user moved on while the pods were listed
+		}
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("Scaling down terminates %d pod(s). These are the most likely, newest first:\n", msg.from-msg.to))
+		for _, name := range msg.victims {
+			b.WriteString("  - " + name + "\n")
+		}
+		b.WriteString(fmt.Sprintf("\nScale deployment %s from %d to %d replicas?", msg.name, msg.from, msg.to))
+		m.textInput.Reset() // Answering "n" returns to an empty scale prompt
+		m.askConfirm(b.String(), scaleDeployment(m.clientset, msg.namespace, msg.name, msg.to))
+		return m, nil
+	case scaleMsg:
+		m.backTo(viewDetails)
+		return m, tea.Batch(getDeployments(m.clientset, m.selectedNamespace), m.watchRollout(msg.namespace, msg.name))
+	case rolloutPolledMsg:
+		key := msg.namespace + "/" + msg.name
+		if msg.err != nil {
+			delete(m.watchedRollouts, key)
+			return m, func() tea.Msg { return errMsg{fmt.Errorf("watching rollout of %s: %w", key, msg.err)} }
+		}
+		notice, ended, failed := rolloutOutcome(msg.deployment)
+		if !ended {
+			return m, pollRollout(m.clientset, msg.namespace, msg.name)
+		}
+		delete(m.watchedRollouts, key)
+		m.rolloutNotice, m.rolloutFailed = notice, failed
+		logger.Info("rollout ended", slog.String("deployment", key), slog.Bool("failed", failed))
+		return m, m.writeToTerminal(bell)
+	case rolloutStatusMsg:
+		m.rolloutDeployment, m.rolloutStatefulSet, m.rolloutDaemonSet = msg.deployment, msg.statefulSet, msg.daemonSet
+		return m.withTick()
+	case daemonSetCoverageMsg:
+		if m.view == viewDetails && m.detailsSource() == viewDaemonSets && m.cursor < len(m.daemonsets) {
+			if d := m.daemonsets[m.cursor]; d.Namespace == msg.namespace && d.Name == msg.name {
+				m.daemonSetCoverage = msg.rows
+				m.details = m.formatDaemonSetDetails(d)
+				offset := m.viewport.YOffset
+				m.setViewportContent(m.detailsView())
+				m.viewport.SetYOffset(offset)
+			}
+		}
+		return m, nil
+	case nodeEventsMsg:
+		// Only append if the user is still looking at this node's details
+		if m.view == viewDetails && m.detailsSource() == viewNodes && m.cursor < len(m.nodes) && m.nodes[m.cursor].Name == msg.node {
+			m.nodeEvents = msg.events
+			m.refreshNodeDetails()
+		}
+		return m, nil
+	case controllerMsg:
+		cmd, ok := m.showResource(msg)
+		if !ok {
+			return m.Update(errMsg{fmt.Errorf("the pod is controlled by %s/%s, which kubeview has no view for", msg.kind, msg.name)})
+		}
+		return m, cmd
+	case execProcessMsg:
+		return m, tea.ExecProcess(msg.cmd, msg.onExit)
+	case nodeMapMsg:
+		m.nodeMap = msg.rows
+		if m.cursor >= len(m.nodeMap) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case resourceCountsMsg:
+		m.resourceCounts = msg.rows
+		if m.cursor >= len(m.resourceCounts) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case copiedMsg:
+		m.copied, m.copiedViaTerminal = msg.text, msg.viaTerminal
+		if msg.viaTerminal {
+			return m, m.writeToTerminal(ansi.SetSystemClipboard(msg.text))
+		}
+		return m, nil
+	case terminalSeqMsg:
+		return m, m.writeToTerminal(string(msg))
+	case clearTerminalSeqMsg:
+		m.terminalSeq = ""
+		return m, nil
+	case searchIndexMsg:
+		m.searchItems = msg.items
+		m.searchCursor = 0
+		if msg.err != nil {
+			return m.Update(errMsg{msg.err})
+		}
+		return m, nil
+	case canIMsg:
+		m.canIResult = &msg
+		return m, nil
+	case applyMsg:
+		m.lastApply = &msg
+		return m, nil
+	case rollbackMsg:
+		m.backTo(viewDetails)
+		return m, getDeployments(m.clientset, m.selectedNamespace)
+	case imageSetMsg:
+		m.backTo(viewDetails)
+		m.textInput.Reset()
+		return m, getDeployments(m.clientset, m.selectedNamespace)
+	case namespaceCreatedMsg:
+		m.backTo(viewNamespaces)
+		m.textInput.Reset()
+		return m, getNamespaces(m.clientset)
+	case namespaceDeletedMsg:
+		m.backTo(viewNamespaces)
+		m.textInput.Reset()
+		m.deletingNamespace = ""
+		m.cursor = 0
+		if m.selectedNamespace == msg.name {
+			m.selectedNamespace = "" // Don't keep listing a namespace that is going away
+			m.stopEventWatch()
+		}
+		return m, getNamespaces(m.clientset)
+	case podDeletedMsg:
+		m.backTo(viewPods)
+		return m, m.fetchPods()
+	case podsDeletedMsg:
+		m.backTo(viewPods)
+		m.cursor = 0
+		return m, m.fetchPods()
+	case workloadRestartedMsg:
+		v, fetch := m.workloadList(msg.workload.kind)
+		m.backTo(v)
+		if msg.workload.kind == "deployment" {
+			return m, tea.Batch(fetch, m.watchRollout(msg.workload.namespace, msg.workload.name))
+		}
+		return m, fetch
+	case workloadDeletedMsg:
+		v, fetch := m.workloadList(msg.workload.kind)
+		m.backTo(v)
+		m.cursor = 0 // The deleted row is gone
+		return m, fetch
+	case namespacesMsg:
+		delete(m.forbidden, viewNamespaces)
+		m.namespaces = msg.namespaces
+		m.pinFavoriteNamespaces()
+		m.cursor = 0
+		return m, nil
+	case nodesMsg:
+		inDetails := m.view == viewDetails && m.detailsSource() == viewNodes && m.cursor < len(m.nodes)
+		var shown string
+		if inDetails {
+			shown = m.nodes[m.cursor].Name
+		}
+		m.nodes = msg.nodes
+		m.nodeMetrics = msg.metrics
+		m.nodeAllocations = msg.allocations
+		m.nodeSummary = summarizeNodes(msg.nodes)
+		if m.cursor >= len(m.nodes) {
+			m.cursor = 0
+		}
+		if inDetails {
+			for i, n := range m.nodes {
+				if n.Name == shown {
+					m.cursor = i
+					m.refreshNodeDetails()
+				}
+			}
+		}
+		return m.withTick()
+	case podsMsg:
+		m.pods = msg.pods
+		m.podMetrics = msg.metrics
+		m.podSummary = summarizePods(msg.pods)
+		if m.podsByRestarts {
+			sortPodsByRestarts(m.pods)
+		}
+		if m.cursor >= len(m.pods) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case pvcsMsg:
+		m.pvcs = msg.pvcs
+		if m.cursor >= len(m.pvcs) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case pvcUsageMsg:
+		m.pvcUsage = msg.usage
+		return m, nil
+	case pvsMsg:
+		m.pvs = msg.pvs
+		if m.cursor >= len(m.pvs) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case deploymentsMsg:
+		m.deployments = msg.deployments
+		m.deploymentSummary = summarizeDeployments(msg.deployments)
+		if m.cursor >= len(m.deployments) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case statefulsetsMsg:
+		m.statefulsets = msg.statefulsets
+		if m.cursor >= len(m.statefulsets) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case daemonsetsMsg:
+		m.daemonsets = msg.daemonsets
+		if m.cursor >= len(m.daemonsets) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case servicesMsg:
+		m.services = msg.services
+		if m.cursor >= len(m.services) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case networkPoliciesMsg:
+		m.netpols = msg.policies
+		if m.cursor >= len(m.netpols) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case eventsMsg:
+		delete(m.forbidden, viewEvents)
+		m.events = msg.events
+		m.eventsUpdated = time.Now()
+		if len(m.events) > maxWatchedEvents {
+			m.events = m.events[:maxWatchedEvents]
+		}
+		if m.cursor >= m.listLenOf(viewEvents) {
+			m.cursor = 0
+		}
+		if m.view == viewEvents && m.eventWatch == nil {
+			var tick tea.Cmd
+			m, tick = m.withTick()
+			return m, tea.Batch(watchEvents(m.clientset, m.selectedNamespace, msg.resourceVersion), tick)
+		}
+		return m.withTick()
+	case eventWatchStartedMsg:
+		if !m.watchingEvents() {
+			msg.watcher.Stop()
+			return m, nil
+		}
+		m.stopEventWatch()
+		m.eventWatch = msg.watcher
+		return m, nextWatchEvent(msg.watcher)
+	case eventWatchMsg:
+		if msg.watcher != m.eventWatch {
+			return m, nil // Stale watcher
+		}
+		if !m.watchingEvents() {
+			m.stopEventWatch()
+			return m, nil
+		}
+		if msg.event.Type == watch.Error {
+			m.stopEventWatch()
+			return m, getEvents(m.clientset, m.selectedNamespace)
+		}
+		m.events = applyEventWatch(m.events, msg.event)
+		m.eventsUpdated = time.Now()
+		if m.view == viewEvents && m.eventFollow {
+			m.cursor = 0 // Stay on the newest event, like tail -f
+		} else if m.view == viewEvents && !m.eventGrouped && m.cursor > 0 && msg.event.Type == watch.Added && m.cursor < len(m.visibleEvents())-1 {
+			m.cursor++ // Keep the same event selected as new ones are prepended
+		}
+		return m, nextWatchEvent(msg.watcher)
+	case eventWatchClosedMsg:
+		if msg.watcher != m.eventWatch {
+			return m, nil
+		}
+		// The API server closes watches periodically; relist to resume
+		m.eventWatch = nil
+		if m.view == viewEvents {
+			return m, getEvents(m.clientset, m.selectedNamespace)
+		}
+		return m, nil
+	case rolesMsg:
+		m.roles = msg.roles
+		if m.cursor >= len(m.roles) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case roleBindingsMsg:
+		m.roleBindings = msg.bindings
+		if m.cursor >= len(m.roleBindings) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case resourceQuotasMsg:
+		m.resourceQuotas = msg.quotas
+		if m.cursor >= len(m.resourceQuotas) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case limitRangesMsg:
+		m.limitRanges = msg.limitRanges
+		if m.cursor >= len(m.limitRanges) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case pdbsMsg:
+		m.pdbs = msg.pdbs
+		if m.cursor >= len(m.pdbs) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case cronJobsMsg:
+		m.cronJobs = msg.cronJobs
+		if m.cursor >= len(m.cronJobs) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case cronJobSuspendedMsg:
+		return m, getCronJobs(m.clientset, m.selectedNamespace)
+	case endpointSlicesMsg:
+		m.endpointSlices = msg.slices
+		if m.cursor >= len(m.endpointSlices) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case crdsMsg:
+		m.crds = msg.crds
+		if m.cursor >= len(m.crds) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case customResourcesMsg:
+		delete(m.forbidden, viewCustomResources)
+		m.customResources = msg.resources
+		if m.cursor >= len(m.customResources) {
+			m.cursor = 0
+		}
+		return m.withTick()
+	case errMsg:
+		// Show the error as a banner and keep refreshing, since most list errors are transient
+		if errors.Is(msg.err, context.DeadlineExceeded) {
+			if errors.As(msg.err, new(refreshError)) {
+				msg.err = fmt.Errorf("%w (API timeout %s, retrying; raise it with -timeout)", msg.err, apiTimeout)
+			} else {
+				msg.err = fmt.Errorf("%w (API timeout %s; raise it with -timeout)", msg.err, apiTimeout)
+			}
+		}
+		logger.Error("showing error", slog.String("view", viewName(m.view)), slog.Any("error", msg.err))
+		m.err = msg
+		m.errSeq++
+		seq := m.errSeq
+		var tick tea.Cmd
+		m, tick = m.withTick()
+		return m, tea.Batch(tea.Tick(errBannerTimeout, func(time.Time) tea.Msg {
+			return clearErrMsg{seq: seq}
+		}), tick)
+	case forbiddenMsg:
+		logger.Warn("list forbidden", slog.String("view", viewName(msg.view)), slog.Any("error", msg.err))
+		// Keep refreshing, so the list shows up once access is granted
+		if m.forbidden == nil {
+			m.forbidden = make(map[viewState]forbiddenMsg)
+		}
+		m.forbidden[msg.view] = msg
+		return m.withTick()
+	case clearErrMsg:
+		if msg.seq == m.errSeq {
+			m.err = nil
+		}
+		return m, nil
+	case diffMsg:
+		m.diffTitle = msg.title
+		m.setViewportContent(m.colorDiff(msg.diff))
+		m.setView(viewDiff)
+		return m, nil
+	case yamlMsg: // New case
+		m.yamlContent = msg.yaml
+		m.yamlObject = msg.obj
+		if m.yamlAsJSON {
+			out, err := encodeJSON(msg.obj)
+			if err != nil {
+				return m.Update(errMsg{err})
+			}
+			m.yamlContent = out
+		}
+		m.setViewportContent(m.yamlView())
+		m.setView(viewYAML)
+		return m, nil
+	case clusterHealthMsg:
+		m.clusterHealth = &msg
+		return m, nil
+	case dashboardMsg: // New case for dashboard metrics
+		m.clusterTotals = msg.totals
+		now := time.Now()
+		m.cpuHistory = appendSample(m.cpuHistory, timeserieslinechart.TimePoint{Time: now, Value: msg.cpuPercent})
+		m.memoryHistory = appendSample(m.memoryHistory, timeserieslinechart.TimePoint{Time: now, Value: msg.memoryPercent})
+		m.topPodsByCPU = msg.topPodsByCPU
+		m.topPodsByMemory = msg.topPodsByMemory
+		m.topNodesByCPU = msg.topNodesByCPU
+		m.topNodesByMemory = msg.topNodesByMemory
+		return m.withTick()
+	case tea.KeyMsg:
+		m.err = nil // Any keypress dismisses the error banner
+		m.copied = ""
+		m.rolloutNotice = ""
+		if m.view == viewConfirm {
+			switch msg.String() {
+			case "y", "Y":
+				action := m.confirmAction
+				m.confirmAction = nil
+				m.popView()
+				return m, action
+			case "n", "N", "esc":
+				m.confirmAction = nil
+				m.popView()
+			}
+			return m, nil
+		}
+		if m.view == viewScaling {
+			switch msg.String() {
+			case "enter":
+				replicaCount, err := strconv.Atoi(m.textInput.Value())
+				if err == nil {
+					return m, m.scaleTo(m.deployments[m.cursor], int32(replicaCount))
+				}
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewSetImage {
+			switch msg.String() {
+			case "enter":
+				d := m.deployments[m.cursor]
+				container, image, err := parseImageInput(m.textInput.Value(), d)
+				if err != nil {
+					return m.Update(errMsg{err})
+				}
+				return m, setImage(m.clientset, d.Namespace, d.Name, container, image)
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewCanI {
+			switch msg.String() {
+			case "enter":
+				query := m.textInput.Value()
+				q, err := parseCanIQuery(query, m.selectedNamespace)
+				if err != nil {
+					return m.Update(errMsg{err})
+				}
+				return m, checkAccess(m.clientset, query, q)
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewDeleteNamespace {
+			switch msg.String() {
+			case "enter":
+				// Deleting a namespace takes everything in it, so a y/n
+				// answer isn't enough: the name has to be typed out.
+				if m.textInput.Value() != m.deletingNamespace {
+					return m.Update(errMsg{fmt.Errorf("type %q to confirm the deletion", m.deletingNamespace)})
+				}
+				return m, deleteNamespace(m.clientset, m.deletingNamespace)
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+				m.deletingNamespace = ""
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewApply {
+			switch msg.String() {
+			case "enter":
+				path := strings.TrimSpace(m.textInput.Value())
+				if path == "" {
+					return m, nil
+				}
+				return m, applyFile(m.clientset, m.dynamicClient, path, m.selectedNamespace)
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewSearch {
+			results := m.searchResults()
+			switch msg.String() {
+			case "enter":
+				if m.searchCursor >= len(results) {
+					return m, nil
+				}
+				ref := results[m.searchCursor]
+				m.popView()
+				m.textInput.Reset()
+				cmd, ok := m.showResource(controllerMsg(ref))
+				if !ok { // ConfigMaps have no list view, so show the YAML instead
+					return m, getResourceYAML(m.clientset, ref.namespace, ref.name, ref.kind)
+				}
+				return m, cmd
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			case "up", "ctrl+k":
+				if m.searchCursor > 0 {
+					m.searchCursor--
+				}
+			case "down", "ctrl+j":
+				if m.searchCursor < len(results)-1 {
+					m.searchCursor++
+				}
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				m.searchCursor = 0
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewPortForward {
+			switch msg.String() {
+			case "enter":
+				svc := m.services[m.cursor]
+				local, port, err := parsePortForward(m.textInput.Value(), svc)
+				if err != nil {
+					return m.Update(errMsg{err})
+				}
+				if _, err := exec.LookPath("kubectl"); err != nil {
+					return m.Update(errMsg{errors.New("port-forwarding needs kubectl on the PATH")})
+				}
+				m.popView()
+				m.textInput.Reset()
+				var stderr bytes.Buffer
+				forward := execProcessMsg{cmd: m.kubectlPortForward(svc, local, port, &stderr), onExit: func(err error) tea.Msg {
+					if err != nil {
+						if out := strings.TrimSpace(stderr.String()); out != "" {
+							lines := strings.Split(out, "\n")
+							err = errors.New(lines[len(lines)-1])
+						}
+						return errMsg{fmt.Errorf("port-forward to %s: %w", svc.Name, err)}
+					}
+					return nil
+				}}
+				return m, func() tea.Msg { return forward }
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewLogGrep {
+			switch msg.String() {
+			case "enter":
+				tail, re, err := parseLogGrep(m.textInput.Value())
+				if err != nil {
+					return m.Update(errMsg{err})
+				}
+				m.popView()
+				m.textInput.Reset()
+				return m, getGrepLogs(m.clientset, m.pods[m.cursor], tail, re)
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewNodeFilter {
+			switch msg.String() {
+			case "enter":
+				node := strings.TrimSpace(m.textInput.Value())
+				if node == "" {
+					return m, nil
+				}
+				m.popView()
+				m.textInput.Reset()
+				return m, m.showNodePods(node)
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewCreateNamespace {
+			switch msg.String() {
+			case "enter":
+				return m, createNamespace(m.clientset, strings.TrimSpace(m.textInput.Value()))
+			case "esc":
+				m.popView()
+				m.textInput.Reset()
+			default:
+				m.textInput, cmd = m.textInput.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if msg.String() == "?" && m.view != viewHelp {
+			m.jumpBuffer = ""
+			m.setView(viewHelp)
+			return m, nil
+		}
+		if m.view == viewRolloutStatus {
+			switch msg.String() {
+			case "esc", "backspace", "q":
+				m.popView()
+			}
+			return m, nil
+		}
+		if m.view == viewLogs {
+			switch msg.String() {
+			case "esc", "backspace", "q":
+				m.popView()
+				m.setViewportContent(m.detailsView())
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewDiff {
+			switch msg.String() {
+			case "esc", "backspace", "q":
+				m.popView()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewYAML { // New view for YAML
+			switch msg.String() {
+			case "esc", "backspace", "q":
+				m.popView()
+				m.setViewportContent(m.detailsView())
+			case "#":
+				m.yamlLineNumbers = !m.yamlLineNumbers
+				offset := m.viewport.YOffset
+				m.setViewportContent(m.yamlView())
+				m.viewport.SetYOffset(offset)
+			case "j":
+				// Re-encode the object already fetched rather than getting it again.
+				var out string
+				var err error
+				if m.yamlAsJSON {
+					out, err = encodeYAML(m.yamlObject)
+				} else {
+					out, err = encodeJSON(m.yamlObject)
+				}
+				if err != nil {
+					return m.Update(errMsg{err})
+				}
+				m.yamlAsJSON = !m.yamlAsJSON
+				m.yamlContent = out
+				m.setViewportContent(m.yamlView())
+				m.viewport.GotoTop()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewDetails {
+			switch msg.String() {
+			case "d":
+				if m.detailsSource() == viewPods {
+					pod := m.pods[m.cursor]
+					m.askConfirm(fmt.Sprintf("Are you sure you want to delete pod %s?", pod.Name),
+						deletePod(m.clientset, pod.Namespace, pod.Name))
+					return m, nil
+				}
+				if w, ok := m.selectedWorkload(m.detailsSource()); ok {
+					m.askConfirm(fmt.Sprintf("Are you sure you want to delete %s? Its pods are deleted too.", w),
+						deleteWorkload(m.clientset, w))
+					return m, nil
+				}
+			case "R":
+				if w, ok := m.selectedWorkload(m.detailsSource()); ok {
+					m.askConfirm(fmt.Sprintf("Restart all pods of %s?", w), restartWorkload(m.clientset, w))
+					return m, nil
+				}
+			case "p":
+				if w, ok := m.selectedWorkload(m.detailsSource()); ok {
+					return m, m.showWorkloadPods(w.kind, w.namespace, w.name, w.selector)
+				}
+			case "r":
+				if m.detailsSource() == viewDeployments {
+					m.setView(viewScaling)
+					m.textInput.CharLimit = 3
+					m.textInput.Width = 5
+					m.textInput.Placeholder = "3"
+					m.textInput.Focus()
+					m.textInput.SetValue(fmt.Sprintf("%d", *m.deployments[m.cursor].Spec.Replicas))
+					return m, nil
+				}
+			case "s":
+				if w, ok := m.selectedWorkload(m.detailsSource()); ok {
+					m.setView(viewRolloutStatus)
+					m.rolloutWorkload = w
+					m.rolloutDeployment, m.rolloutStatefulSet, m.rolloutDaemonSet = nil, nil, nil
+					return m, getRolloutStatus(m.clientset, w)
+				}
+			case "u":
+				if m.detailsSource() == viewDeployments {
+					d := m.deployments[m.cursor]
+					m.askConfirm(fmt.Sprintf("Roll back deployment %s to its previous revision?", d.Name),
+						rollbackDeployment(m.clientset, d.Namespace, d.Name))
+					return m, nil
+				}
+			case "i":
+				if m.detailsSource() == viewDeployments {
+					m.setView(viewSetImage)
+					m.textInput.CharLimit = 0
+					m.textInput.Width = 60
+					m.textInput.Placeholder = "container=image:tag"
+					m.textInput.Focus()
+					m.textInput.Reset()
+					return m, nil
+				}
+			case "l":
+				if m.detailsSource() == viewPods {
+					pod := m.pods[m.cursor]
+					return m, getLogs(m.clientset, pod.Namespace, pod.Name)
+				}
+				if w, ok := m.selectedWorkload(m.detailsSource()); ok {
+					return m, getWorkloadLogs(m.clientset, w.namespace, w.selector)
+				}
+			case "P":
+				if m.detailsSource() == viewPods {
+					return m, getPreviousLogs(m.clientset, m.pods[m.cursor])
+				}
+			case "g":
+				if m.detailsSource() == viewPods {
+					m.setView(viewLogGrep)
+					m.textInput.CharLimit = 0
+					m.textInput.Width = 40
+					m.textInput.Placeholder = "tail-lines pattern"
+					m.textInput.SetValue(fmt.Sprintf("%d ", defaultLogGrepTailLines))
+					m.textInput.Focus()
+					return m, nil
+				}
+			case "y": // New keybinding for YAML
+				if m.detailsSource() == viewCustomResources {
+					cr := m.customResources[m.cursor]
+					return m, getCustomResourceYAML(m.dynamicClient, m.selectedCRD, cr.GetNamespace(), cr.GetName())
+				}
+				ref, ok := m.selectedResource(m.detailsSource())
+				if !ok {
+					return m, nil
+				}
+				return m, getResourceYAML(m.clientset, ref.namespace, ref.name, ref.kind)
+			case "ctrl+y":
+				if fields := detailFields(m.details); m.detailsField < len(fields) {
+					return m, copyToClipboard(fields[m.detailsField].value)
+				}
+			case "[":
+				m.moveDetailsField(-1)
+				return m, nil
+			case "]":
+				m.moveDetailsField(1)
+				return m, nil
+			case "D":
+				ref, ok := m.describeTarget(m.detailsSource())
+				if !ok {
+					return m, nil
+				}
+				if _, err := exec.LookPath("kubectl"); err != nil {
+					return m, func() tea.Msg { return errMsg{errors.New("kubectl describe needs kubectl on the PATH")} }
+				}
+				describe := execProcessMsg{cmd: m.kubectlDescribe(ref), onExit: func(err error) tea.Msg {
+					if err != nil {
+						return errMsg{fmt.Errorf("kubectl describe: %w", err)}
+					}
+					return nil
+				}}
+				return m, func() tea.Msg { return describe }
+			case "esc", "backspace":
+				m.popView()
+			default:
+				m.viewport, cmd = m.viewport.Update(msg)
+				cmds = append(cmds, cmd)
+			}
+			return m, tea.Batch(cmds...)
+		}
+		if m.view == viewHelp {
+			switch msg.String() {
+			case "esc", "backspace", "q", "?":
+				m.popView()
+			}
+			return m, nil
+		}
+		if m.view == viewNamespaces {
+			switch msg.String() {
+			case "enter":
+				if m.cursor == 0 {
+					m.selectedNamespace = "" // All namespaces
+				} else {
+					m.selectedNamespace = m.namespaces[m.cursor-1].Name
+				}
+				m.stopEventWatch() // The watch is scoped to the old namespace
+				m.popView()
+				m.cursor = 0
+				updatedModel, cmd := m.Update(tickMsg{})
+				return updatedModel, cmd
+			case "esc", "backspace", "N":
+				m.popView()
+				m.cursor = 0
+			case "a":
+				m.setView(viewCreateNamespace)
+				m.textInput.CharLimit = validation.DNS1123LabelMaxLength
+				m.textInput.Width = validation.DNS1123LabelMaxLength
+				m.textInput.Placeholder = "my-namespace"
+				m.textInput.Reset()
+				m.textInput.Focus()
+				return m, nil
+			case "d":
+				if m.cursor > 0 {
+					m.deletingNamespace = m.namespaces[m.cursor-1].Name
+					m.setView(viewDeleteNamespace)
+					m.textInput.CharLimit = validation.DNS1123LabelMaxLength
+					m.textInput.Width = validation.DNS1123LabelMaxLength
+					m.textInput.Placeholder = m.deletingNamespace
+					m.textInput.Reset()
+					m.textInput.Focus()
+					return m, nil
+				}
+			case "f":
+				if m.cursor > 0 {
+					name := m.namespaces[m.cursor-1].Name
+					m.toggleFavoriteNamespace(name)
+					for i, ns := range m.namespaces {
+						if ns.Name == name {
+							m.cursor = i + 1 // Keep the toggled namespace selected after re-pinning
+						}
+					}
+				}
+			case "ctrl+y":
+				if name, ok := m.selectedName(viewNamespaces); ok {
+					return m, copyToClipboard(name)
+				}
+			case "up":
+				if m.cursor > 0 {
+					m.cursor--
+				}
+			case "down":
+				if m.cursor < len(m.namespaces) {
+					m.cursor++
+				}
+			}
+			return m, nil
+		}
+		if m.view == viewResourceMenu {
+			switch msg.String() {
+			case "enter":
+				items := m.menuItems()
+				if m.cursor >= len(items) {
+					return m, nil
+				}
+				switch items[m.cursor] {
+				case "Nodes":
+					return m, m.openList(viewNodes, getNodes(m.clientset, m.metricsClientset))
+				case "Pods":
+					m.clearPodFilter()
+					return m, m.openList(viewPods, m.fetchPods())
+				case "Deployments":
+					return m, m.openList(viewDeployments, getDeployments(m.clientset, m.selectedNamespace))
+				case "StatefulSets":
+					return m, m.openList(viewStatefulSets, getStatefulSets(m.clientset, m.selectedNamespace))
+				case "DaemonSets":
+					return m, m.openList(viewDaemonSets, getDaemonSets(m.clientset, m.selectedNamespace))
+				case "Services":
+					return m, m.openList(viewServices, getServices(m.clientset, m.selectedNamespace))
+				case "PVCs":
+					m.pvcUsageStamp = fetchStamp{} // Reread on entry, however recent
+					return m, m.openList(viewPVCs, tea.Batch(getPVCs(m.clientset, m.selectedNamespace), m.refreshPVCUsage()))
+				case "PVs":
+					return m, m.openList(viewPVs, getPVs(m.clientset))
+				case "Network Policies":
+					return m, m.openList(viewNetworkPolicies, getNetworkPolicies(m.clientset, m.selectedNamespace))
+				case "Events":
+					return m, m.openList(viewEvents, getEvents(m.clientset, m.selectedNamespace))
+				case "Roles":
+					return m, m.openList(viewRoles, getRoles(m.clientset, m.selectedNamespace))
+				case "RoleBindings":
+					return m, m.openList(viewRoleBindings, getRoleBindings(m.clientset, m.selectedNamespace))
+				case "ResourceQuotas":
+					return m, m.openList(viewResourceQuotas, getResourceQuotas(m.clientset, m.selectedNamespace))
+				case "LimitRanges":
+					return m, m.openList(viewLimitRanges, getLimitRanges(m.clientset, m.selectedNamespace))
+				case "CronJobs":
+					return m, m.openList(viewCronJobs, getCronJobs(m.clientset, m.selectedNamespace))
+				case "PDBs":
+					return m, m.openList(viewPDBs, getPDBs(m.clientset, m.selectedNamespace))
+				case "EndpointSlices":
+					return m, m.openList(viewEndpointSlices, getEndpointSlices(m.clientset, m.selectedNamespace))
+				case "Custom Resources":
+					return m, m.openList(viewCRDs, getCRDs(m.dynamicClient))
+				case "Node Map":
+					return m, m.openList(viewNodeMap, getNodeMap(m.clientset, m.metricsClientset))
+				case "Resource Counts":
+					return m, m.openList(viewResourceCounts, getResourceCounts(m.metadataClient))
+				}
+			case "esc":
+				m.popView()
+				m.cursor = 0
+			case "backspace":
+				if m.menuFilter == "" {
+					m.popView()
+					m.cursor = 0
+					return m, nil
+				}
+				m.menuFilter = m.menuFilter[:len(m.menuFilter)-1]
+				m.cursor = 0
+			case "up":
+				if m.cursor > 0 {
+					m.cursor--
+				}
+			case "down":
+				if m.cursor < len(m.menuItems())-1 {
+					m.cursor++
+				}
+			default:
+				if msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace {
+					m.menuFilter += msg.String()
+					m.cursor = 0
+				}
+			}
+			return m, nil
+		}
+
+		if m.jumpBuffer != "" {
+			switch msg.String() {
+			case "enter":
+				if n, err := strconv.Atoi(m.jumpBuffer); err == nil && n >= 1 && n <= m.listLen() {
+					m.cursor = n - 1
+				}
+				m.jumpBuffer = ""
+				return m, nil
+			case "backspace":
+				m.jumpBuffer = m.jumpBuffer[:len(m.jumpBuffer)-1]
+				return m, nil
+			case "esc":
+				m.jumpBuffer = ""
+				return m, nil
+			case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			default:
+				m.jumpBuffer = ""
+			}
+		}
+
+		switch msg.String() {
+		case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+			if m.listLen() > 0 && len(m.jumpBuffer) < 6 {
+				m.jumpBuffer += msg.String()
+			}
+			return m, nil
+		case "q", "ctrl+c":
+			m.shutdown()
+			return m, tea.Quit
+		case "r":
+			m.setView(viewResourceMenu)
+			m.menuFilter = ""
+			m.cursor = 0 // Reset cursor for the new menu
+			return m, nil
+		case "N":
+			m.setView(viewNamespaces)
+			return m, getNamespaces(m.clientset)
+		case "F":
+			if len(m.favoriteNamespaces) == 0 {
+				return m, nil
+			}
+			m.selectedNamespace = nextFavorite(m.favoriteNamespaces, m.selectedNamespace)
+			m.stopEventWatch() // The watch is scoped to the old namespace
+			m.cursor = 0
+			return m.Update(tickMsg{})
+		case "U":
+			m.wholeUnits = !m.wholeUnits
+			return m, nil
+		case "z":
+			// Narrow an all-namespaces list down to the selected item's namespace
+			ref, ok := m.selectedResource(m.view)
+			if !ok || !listViews[m.view] || m.selectedNamespace != "" || ref.namespace == "" {
+				return m, nil
+			}
+			m.selectedNamespace = ref.namespace
+			m.stopEventWatch() // The watch is scoped to the old namespace
+			if f := m.podFilter; f != nil && f.namespace == "" {
+				scoped := *f
+				scoped.namespace = ref.namespace
+				m.podFilter = &scoped
+			}
+			m.rowTarget = &controllerMsg{kind: ref.kind, namespace: ref.namespace, name: ref.name}
+			m.cursor = 0
+			return m.Update(tickMsg{})
+		case "ctrl+y":
+			if name, ok := m.selectedName(m.view); ok && listViews[m.view] {
+				return m, copyToClipboard(name)
+			}
+		case "ctrl+p":
+			m.setView(viewSearch)
+			m.searchItems, m.searchCursor = nil, 0
+			m.textInput.CharLimit = 0
+			m.textInput.Width = 40
+			m.textInput.Placeholder = "name"
+			m.textInput.Reset()
+			m.textInput.Focus()
+			return m, getSearchIndex(m.metadataClient, m.selectedNamespace)
+		case "A":
+			m.setView(viewCanI)
+			m.canIResult = nil
+			m.textInput.CharLimit = 0
+			m.textInput.Width = 60
+			m.textInput.Placeholder = "list pods -n default --as default:my-sa"
+			m.textInput.Reset()
+			m.textInput.Focus()
+			return m, nil
+		case "n":
+			if m.view == viewPods {
+				m.setView(viewNodeFilter)
+				m.textInput.CharLimit = 0
+				m.textInput.Width = 40
+				m.textInput.Placeholder = "node name"
+				m.textInput.Reset()
+				m.textInput.Focus()
+				return m, nil
+			}
+		case "p":
+			if m.view == viewNodes && len(m.nodes) > 0 {
+				return m, m.showNodePods(m.nodes[m.cursor].Name)
+			}
+			if w, ok := m.selectedWorkload(m.view); ok {
+				return m, m.showWorkloadPods(w.kind, w.namespace, w.name, w.selector)
+			}
+		case "m":
+			ref, ok := m.selectedResource(m.view)
+			if !ok {
+				return m, nil
+			}
+			mark := m.diffMark
+			if mark == nil || mark.kind != ref.kind {
+				m.diffMark = &ref
+				return m, nil
+			}
+			m.diffMark = nil
+			if *mark == ref {
+				return m, nil // Pressing m on the marked row again unmarks it
+			}
+			return m, getResourceDiff(m.clientset, *mark, ref)
+		case "+", "-":
+			if m.view == viewDeployments && len(m.deployments) > 0 {
+				d := m.deployments[m.cursor]
+				replicas := int32(1)
+				if d.Spec.Replicas != nil {
+					replicas = *d.Spec.Replicas
+				}
+				if msg.String() == "+" {
+					replicas++
+				} else if replicas > 0 {
+					replicas--
+				} else {
+					return m, nil
+				}
+				return m, m.scaleTo(d, replicas)
+			}
+		case "a":
+			m.setView(viewApply)
+			m.lastApply = nil
+			m.textInput.CharLimit = 0
+			m.textInput.Width = 60
+			m.textInput.Placeholder = "./manifests/app.yaml"
+			m.textInput.Reset()
+			m.textInput.Focus()
+			return m, nil
+		case "D": // New keybinding for Dashboard
+			m.setView(viewDashboard)
+			return m, tea.Batch(
+				getDashboardMetrics(m.clientset, m.metricsClientset, m.dashboardNamespace(), m.topN),
+				getClusterHealth(m.clientset),
+			)
+		case "s":
+			if m.view == viewDashboard {
+				m.dashboardScoped = !m.dashboardScoped
+				m.cpuHistory, m.memoryHistory = nil, nil // Samples from the other scope aren't comparable
+				return m, getDashboardMetrics(m.clientset, m.metricsClientset, m.dashboardNamespace(), m.topN)
+			}
+			if m.view == viewCronJobs && len(m.cronJobs) > 0 {
+				c := m.cronJobs[m.cursor]
+				return m, setCronJobSuspend(m.clientset, c.Namespace, c.Name, !cronJobSuspended(c))
+			}
+			if m.view == viewPods {
+				m.podsByRestarts = !m.podsByRestarts
+				m.cursor = 0
+				if m.podsByRestarts {
+					sortPodsByRestarts(m.pods)
+					return m, nil
+				}
+				return m, m.fetchPods() // Refetch to restore the API's order
+			}
+		case "C":
+			if m.view == viewPods {
+				finished := finishedPods(m.pods)
+				if len(finished) == 0 {
+					return m, func() tea.Msg { return errMsg{errors.New("no completed, failed or evicted pods to clean up")} }
+				}
+				scope := "in " + m.selectedNamespace
+				if m.selectedNamespace == "" {
+					scope = "across all namespaces"
+				}
+				if f := m.podFilter; f != nil {
+					scope = "of " + f.owner
+				}
+				m.askConfirm(fmt.Sprintf("Delete %d completed, failed or evicted pod(s) %s?", len(finished), scope), deletePods(m.clientset, finished))
+				return m, nil
+			}
+		case "f":
+			if m.view == viewServices && len(m.services) > 0 {
+				svc := m.services[m.cursor]
+				if len(svc.Spec.Ports) == 0 {
+					return m, func() tea.Msg { return errMsg{fmt.Errorf("service %s exposes no ports", svc.Name)} }
+				}
+				port := svc.Spec.Ports[0].Port
+				m.setView(viewPortForward)
+				m.textInput.CharLimit = 0
+				m.textInput.Width = 20
+				m.textInput.Placeholder = "local:port"
+				m.textInput.SetValue(fmt.Sprintf("%d:%d", suggestedLocalPort(port), port))
+				m.textInput.Focus()
+				return m, nil
+			}
+			if m.view == viewEvents {
+				m.eventFollow = !m.eventFollow
+				m.cursor = 0
+				if m.eventFollow && m.eventWatch == nil {
+					return m, getEvents(m.clientset, m.selectedNamespace) // Relisting restarts the watch
+				}
+				return m, nil
+			}
+		case "w":
+			if m.view == viewEvents {
+				m.eventWarningsOnly = !m.eventWarningsOnly
+				m.cursor = 0
+			} else if listViews[m.view] {
+				m.wideLists = !m.wideLists
+			}
+		case "g":
+			if m.view == viewEvents {
+				m.eventGrouped = !m.eventGrouped
+				m.cursor = 0
+			}
+		case "o":
+			if m.view == viewPods && len(m.pods) > 0 {
+				return m, getController(m.clientset, m.pods[m.cursor])
+			}
+			if m.view == viewEvents {
+				for i, kind := range eventKindFilters {
+					if kind == m.eventKindFilter {
+						m.eventKindFilter = eventKindFilters[(i+1)%len(eventKindFilters)]
+						break
+					}
+				}
+				m.cursor = 0
+			}
+		case "t":
+			if m.view == viewDashboard {
+				m.topN = nextTopN(m.topN)
+				return m, getDashboardMetrics(m.clientset, m.metricsClientset, m.dashboardNamespace(), m.topN)
+			}
+		case "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < m.listLen()-1 {
+				m.cursor++
+			}
+		case "esc", "backspace":
+			if len(m.viewStack) == 0 {
+				return m, nil
+			}
+			if m.view == viewPods {
+				m.clearPodFilter()
+			}
+			m.popView()
+			m.cursor = 0 // The cursor indexed the list being left
+			if m.view == viewCRDs {
+				return m, getCRDs(m.dynamicClient)
+			}
+		case "enter":
+			if m.view == viewNodeMap {
+				if m.cursor >= len(m.nodeMap) || m.nodeMap[m.cursor].node == "" {
+					return m, nil
+				}
+				row := m.nodeMap[m.cursor]
+				cmd := m.showNodePods(row.node)
+				if row.pod != nil {
+					m.rowTarget = &controllerMsg{kind: "Pod", namespace: row.pod.Namespace, name: row.pod.Name}
+				}
+				return m, cmd
+			}
+			if m.view == viewCRDs {
+				if len(m.crds) == 0 {
+					return m, nil
+				}
+				m.selectedCRD = m.crds[m.cursor]
+				m.customResources = nil
+				m.setView(viewCustomResources)
+				m.cursor = 0
+				return m, getCustomResources(m.dynamicClient, m.selectedCRD, m.selectedNamespace)
+			}
+			source := m.view
+			m.setView(viewDetails)
+			m.detailsField = 0
+			switch source {
+			case viewNodes:
+				node := m.nodes[m.cursor]
+				metrics, hasMetrics := m.nodeMetrics[node.Name]
+				m.nodeEvents = nil
+				m.details = m.formatNodeDetails(node, metrics, hasMetrics)
+				cmd = getNodeEvents(m.clientset, node.Name)
+			case viewPods:
+				pod := m.pods[m.cursor]
+				metrics, hasMetrics := m.podMetrics[pod.Name]
+				m.details = m.formatPodDetails(pod, metrics, hasMetrics)
+			case viewPVCs:
+				m.details = m.formatPVCDetails(m.pvcs[m.cursor])
+			case viewPVs:
+				m.details = m.formatPVDetails(m.pvs[m.cursor])
+			case viewDeployments:
+				m.details = m.formatDeploymentDetails(m.deployments[m.cursor])
+			case viewStatefulSets:
+				m.details = m.formatStatefulSetDetails(m.statefulsets[m.cursor])
+			case viewDaemonSets:
+				d := m.daemonsets[m.cursor]
+				m.daemonSetCoverage = nil
+				m.details = m.formatDaemonSetDetails(d)
+				cmd = getDaemonSetCoverage(m.clientset, d)
+			case viewServices:
+				m.details = m.formatServiceDetails(m.services[m.cursor])
+			case viewNetworkPolicies:
+				m.details = m.formatNetworkPolicyDetails(m.netpols[m.cursor])
+			case viewEvents:
+				if m.eventGrouped {
+					m.details = m.formatEventGroupDetails(groupEvents(m.visibleEvents())[m.cursor])
+				} else {
+					m.details = m.formatEventDetails(m.visibleEvents()[m.cursor])
+				}
+			case viewRoles:
+				m.details = m.formatRoleDetails(m.roles[m.cursor])
+			case viewRoleBindings:
+				m.details = m.formatRoleBindingDetails(m.roleBindings[m.cursor])
+			case viewResourceQuotas:
+				m.details = m.formatResourceQuotaDetails(m.resourceQuotas[m.cursor])
+			case viewLimitRanges:
+				m.details = m.formatLimitRangeDetails(m.limitRanges[m.cursor])
+			case viewPDBs:
+				m.details = m.formatPDBDetails(m.pdbs[m.cursor])
+			case viewCronJobs:
+				m.details = m.formatCronJobDetails(m.cronJobs[m.cursor])
+			case viewEndpointSlices:
+				m.details = m.formatEndpointSliceDetails(m.endpointSlices[m.cursor])
+			case viewCustomResources:
+				m.details = m.formatCustomResourceDetails(m.customResources[m.cursor])
+			}
+			m.setViewportContent(m.detailsView())
+			return m, cmd
+		}
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m model) headerView() string {
+	var title string
+	nsText := "all namespaces"
+	if len(m.excludedNamespaces) > 0 {
+		nsText += " except " + strings.Join(m.excludedNamespaces, ", ")
+	}
+	if m.selectedNamespace != "" {
+		nsText = m.selectedNamespace
+	}
+
+	switch m.view {
+	case viewNodes:
+		title = "Nodes"
+	case viewPods:
+		title = fmt.Sprintf("Pods in %s", nsText)
+		if f := m.podFilter; f != nil {
+			filterNs := "all namespaces"
+			if f.namespace != "" {
+				filterNs = f.namespace
+			}
+			title = fmt.Sprintf("Pods of %s in %s", f.owner, filterNs)
+		}
+		if m.podsByRestarts {
+			title += ", by restarts"
+		}
+	case viewPVCs:
+		title = fmt.Sprintf("PVCs in %s", nsText)
+	case viewPVs:
+		title = "PersistentVolumes"
+	case viewDeployments:
+		title = fmt.Sprintf("Deployments in %s", nsText)
+	case viewStatefulSets:
+		title = fmt.Sprintf("StatefulSets in %s", nsText)
+	case viewDaemonSets:
+		title = fmt.Sprintf("DaemonSets in %s", nsText)
+	case viewServices:
+		title = fmt.Sprintf("Services in %s", nsText)
+	case viewNetworkPolicies:
+		title = fmt.Sprintf("Network Policies in %s", nsText)
+	case viewEvents:
+		title = fmt.Sprintf("Events in %s", nsText)
+		if m.eventWarningsOnly {
+			title += ", warnings only"
+		}
+		if m.eventKindFilter != "" {
+			title += fmt.Sprintf(", %s only", m.eventKindFilter)
+		}
+		if m.eventGrouped {
+			title += ", grouped by object"
+		}
+		if m.eventWatch != nil {
+			title += " (live)"
+		}
+		if m.eventFollow {
+			title += ", following"
+		}
+	case viewRoles:
+		title = fmt.Sprintf("Roles in %s", nsText)
+	case viewRoleBindings:
+		title = fmt.Sprintf("RoleBindings in %s", nsText)
+	case viewCanI:
+		title = "Access Check (can-i)"
+	case viewApply:
+		title = "Apply File"
+	case viewSearch:
+		title = fmt.Sprintf("Search in %s", nsText)
+	case viewNodeMap:
+		title = "Node Map (pods by node, all namespaces)"
+	case viewResourceCounts:
+		title = "Resource Counts (all namespaces)"
+	case viewNodeFilter:
+		title = "Pods on Node"
+	case viewPortForward:
+		title = fmt.Sprintf("Port-forward Service: %s", m.services[m.cursor].Name)
+	case viewLogGrep:
+		title = fmt.Sprintf("Grep Logs: %s", m.pods[m.cursor].Name)
+	case viewCreateNamespace:
+		title = "Create Namespace"
+	case viewDeleteNamespace:
+		title = fmt.Sprintf("Delete Namespace %s", m.deletingNamespace)
+	case viewResourceQuotas:
+		title = fmt.Sprintf("ResourceQuotas in %s", nsText)
+	case viewLimitRanges:
+		title = fmt.Sprintf("LimitRanges in %s", nsText)
+	case viewPDBs:
+		title = fmt.Sprintf("PodDisruptionBudgets in %s", nsText)
+	case viewCronJobs:
+		title = fmt.Sprintf("CronJobs in %s", nsText)
+	case viewEndpointSlices:
+		title = fmt.Sprintf("EndpointSlices in %s", nsText)
+	case viewCRDs:
+		title = "Custom Resource Definitions"
+	case viewCustomResources:
+		title = m.selectedCRD.kind
+		if m.selectedCRD.namespaced {
+			title = fmt.Sprintf("%s in %s", m.selectedCRD.kind, nsText)
+		}
+	case viewNamespaces:
+		title = "Select Namespace"
+	case viewResourceMenu:
+		title = "Select Resource"
+	case viewHelp:
+		title = "Help"
+	case viewDetails:
+		title = "Details"
+	case viewLogs:
+		title = fmt.Sprintf("Logs for %s", m.logsSource)
+	case viewScaling:
+		d := m.deployments[m.cursor]
+		title = fmt.Sprintf("Scale Deployment: %s", d.Name)
+	case viewSetImage:
+		d := m.deployments[m.cursor]
+		title = fmt.Sprintf("Set Image: %s", d.Name)
+	case viewRolloutStatus:
+		title = fmt.Sprintf("Rollout Status: %s", m.rolloutWorkload)
+	case viewConfirm:
+		title = "Confirm"
+	case viewDiff:
+		title = "Diff: " + m.diffTitle
+	case viewYAML:
+		title = "YAML Details"
+		if m.yamlAsJSON {
+			title = "JSON Details"
+		}
+	case viewDashboard: // New case
+		title = "Cluster Dashboard"
+		if ns := m.dashboardNamespace(); ns != "" {
+			title = fmt.Sprintf("Dashboard for %s", ns)
+		}
+	}
+	if summary := m.listSummary(); summary != "" {
+		return m.styles.HeaderText.Render(title) + "  " + m.styles.Muted.Render(summary)
+	}
+	return m.styles.HeaderText.Render(title)
+}
+
+// listSummary returns the status line for the current list view.
+func (m model) listSummary() string {
+	switch m.view {
+	case viewNodes:
+		return m.nodeSummary
+	case viewPods:
+		return m.podSummary
+	case viewDeployments:
+		return m.deploymentSummary
+	}
+	if listViews[m.view] {
+		return fmt.Sprintf("Total: %d", m.listLen())
+	}
+	return ""
+}
+
+// withProblems formats a count followed by the non-empty problem counts, e.g. "Pods: 12 (2 not ready)".
+func withProblems(label string, total int, problems ...string) string {
+	s := fmt.Sprintf("%s: %d", label, total)
+	if len(problems) > 0 {
+		s += " (" + strings.Join(problems, ", ") + ")"
+	}
+	return s
+}
+
+func summarizeNodes(nodes []v1.Node) string {
+	var notReady, cordoned int
+	for _, node := range nodes {
+		if !strings.HasPrefix(getNodeStatus(node), "Ready") {
+			notReady++
+		}
+		if node.Spec.Unschedulable {
+			cordoned++
+		}
+	}
+	var problems []string
+	if notReady > 0 {
+		problems = append(problems, fmt.Sprintf("%d NotReady", notReady))
+	}
+	if cordoned > 0 {
+		problems = append(problems, fmt.Sprintf("%d cordoned", cordoned))
+	}
+	return withProblems("Nodes", len(nodes), problems...)
+}
+
+func summarizePods(pods []v1.Pod) string {
+	var notReady, crashLooping, failed int
+	for _, pod := range pods {
+		switch {
+		case pod.Status.Phase == v1.PodSucceeded:
+			continue
+		case pod.Status.Phase == v1.PodFailed:
+			failed++
+			continue
+		case getPodStatus(pod) == "CrashLoopBackOff":
+			crashLooping++
+		}
+		if !isPodReady(pod) {
+			notReady++
+		}
+	}
+	var problems []string
+	if notReady > 0 {
+		problems = append(problems, fmt.Sprintf("%d not ready", notReady))
+	}
+	if crashLooping > 0 {
+		problems = append(problems, fmt.Sprintf("%d crashlooping", crashLooping))
+	}
+	if failed > 0 {
+		problems = append(problems, fmt.Sprintf("%d failed", failed))
+	}
+	return withProblems("Pods", len(pods), problems...)
+}
+
+func summarizeDeployments(deployments []appsv1.Deployment) string {
+	var unavailable int
+	for _, d := range deployments {
+		desired := int32(1)
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		if d.Status.AvailableReplicas < desired {
+			unavailable++
+		}
+	}
+	var problems []string
+	if unavailable > 0 {
+		problems = append(problems, fmt.Sprintf("%d unavailable", unavailable))
+	}
+	return withProblems("Deployments", len(deployments), problems...)
+}
+
+// podRestarts returns the total restart count of a pod's containers.
+func podRestarts(pod v1.Pod) int32 {
+	var restarts int32
+	for _, cs := range pod.Status.ContainerStatuses {
+		restarts += cs.RestartCount
+	}
+	return restarts
+}
+
+// sortPodsByRestarts orders pods by restart count, highest first, keeping the
+// existing order for equal counts.
+func sortPodsByRestarts(pods []v1.Pod) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		return podRestarts(pods[i]) > podRestarts(pods[j])
+	})
+}
+
+// isPodReady reports whether the pod's Ready condition is true.
+func isPodReady(pod v1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == v1.PodReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func (m model) footerView() string {
+	if m.view == viewHelp {
+		return m.styles.Muted.Render("(esc) back")
+	}
+
+	help := "(q)uit | (r)esources | (D)ash | (N)s | (A)ccess | (a)pply | (?) help"
+
+	if m.view == viewDetails {
+		baseHelp := "(esc) back"
+		switch m.detailsSource() {
+		case viewPods:
+			baseHelp += " | (l)ogs | (P)revious logs | (g)rep logs | (d)elete | (y)aml"
+		case viewDeployments:
+			baseHelp += " | (l)ogs | (p)ods | (R)estart | (d)elete | (r)eplicas | (i)mage | rollout (s)tatus | (u)ndo | (y)aml"
+		case viewStatefulSets, viewDaemonSets:
+			baseHelp += " | (l)ogs | (p)ods | (R)estart | (d)elete | rollout (s)tatus | (y)aml"
+		default:
+			baseHelp += " | (y)aml"
+		}
+		baseHelp += " | (D)escribe"
+		help = baseHelp
+	}
+	if m.view == viewLogs {
+		help = "(esc) back to details"
+	}
+	if m.view == viewYAML {
+		help = "(esc) back to details | (#) line numbers | (j)son/yaml"
+	}
+	if m.view == viewDiff {
+		help = "(esc) back"
+	}
+	if mark := m.diffMark; mark != nil && listViews[m.view] {
+		help += fmt.Sprintf(" | (m) diff with %s", mark)
+	}
+	if listViews[m.view] && m.selectedNamespace == "" && !clusterScopedViews[m.view] {
+		help += " | (z) its namespace"
+	}
+	if m.view == viewDashboard {
+		help += " | (t)op-N | (s)cope"
+	}
+	if m.view == viewEvents {
+		help += " | (f)ollow | (w)arnings | (o)bject kind | (g)roup"
+	}
+	if m.view == viewPods {
+		help += " | (s)ort by restarts | (o)wner | (n)ode | (C)lean up finished"
+	}
+	if m.view == viewCronJobs {
+		help += " | (s)uspend/resume"
+	}
+	if m.view == viewServices {
+		help += " | (f)orward port"
+	}
+	if m.view == viewDeployments {
+		help += " | (p)ods | (+/-) replicas"
+	}
+	if m.view == viewStatefulSets || m.view == viewDaemonSets || m.view == viewNodes {
+		help += " | (p)ods"
+	}
+	if m.view == viewNodeMap {
+		help += " | (enter) pods on node"
+	}
+	if m.view == viewRolloutStatus {
+		help = "(esc) back to details"
+	}
+	if m.view == viewScaling || m.view == viewSetImage {
+		help = "(enter) confirm | (esc) cancel"
+	}
+	if m.view == viewCanI {
+		help = "(enter) check | (esc) back"
+	}
+	if m.view == viewApply {
+		help = "(enter) apply | (esc) back"
+	}
+	if m.view == viewNodeFilter {
+		help = "(enter) filter | (esc) cancel"
+	}
+	if m.view == viewPortForward {
+		help = "(enter) forward until ctrl+c | (esc) cancel"
+	}
+	if m.view == viewLogGrep {
+		help = "(enter) grep | (esc) cancel"
+	}
+	if m.view == viewSearch {
+		help = "(↑/↓) select | (enter) go to | (esc) cancel"
+	}
+	if m.view == viewCreateNamespace {
+		help = "(enter) create | (esc) cancel"
+	}
+	if m.view == viewDeleteNamespace {
+		help = "(enter) delete | (esc) cancel"
+	}
+	if m.view == viewConfirm {
+		help = "(y)es / (n)o"
+	}
+	if m.view == viewResourceMenu {
+		help = "type to filter | (enter) select | (esc) back"
+	}
+	if m.view == viewNamespaces {
+		help = "(enter) select | (a)dd | (d)elete | (f)avorite | (esc) back"
+	}
+	if m.jumpBuffer != "" {
+		return m.styles.Muted.Render(fmt.Sprintf("Go to row: %s | (enter) jump | (esc) cancel", m.jumpBuffer))
+	}
+	if m.copied != "" {
+		if m.copiedViaTerminal {
+			return m.styles.Muted.Render(fmt.Sprintf("No system clipboard; asked the terminal to copy %s (OSC 52)", m.copied))
+		}
+		return m.styles.Success.Render(fmt.Sprintf("Copied %s to the clipboard", m.copied))
+	}
+	if m.rolloutNotice != "" {
+		if m.rolloutFailed {
+			return m.styles.Error.Render(m.rolloutNotice)
+		}
+		return m.styles.Success.Render(m.rolloutNotice)
+	}
+	return m.styles.Muted.Render(help) + m.freshness()
+}
+
+// updatedAt returns when the data shown in the current view last arrived.
+func (m model) updatedAt() (time.Time, bool) {
+	switch {
+	case m.view == viewEvents:
+		return m.eventsUpdated, !m.eventsUpdated.IsZero()
+	case m.view == viewDashboard:
+		if len(m.cpuHistory) == 0 {
+			return time.Time{}, false
+		}
+		return m.cpuHistory[len(m.cpuHistory)-1].Time, true
+	case listViews[m.view]:
+		stamp, ok := m.fetched[m.view]
+		return stamp.at, ok
+	}
+	return time.Time{}, false
+}
+
+// freshness tells how old the current view's data is, so a view that stopped
+// refreshing (after an error or a network hiccup) doesn't pass for current.
+// The live Events watch pushes changes, so it can be quiet without being stale.
+func (m model) freshness() string {
+	at, ok := m.updatedAt()
+	if !ok {
+		return ""
+	}
+	text := " | updated " + formatAge(metav1.NewTime(at)) + " ago"
+	if m.view == viewEvents && m.eventWatch != nil {
+		return m.styles.Success.Render(" | ● live") + m.styles.Muted.Render(text)
+	}
+	if time.Since(at) > 2*refreshInterval {
+		return m.styles.Warning.Render(text + " (stale)")
+	}
+	return m.styles.Muted.Render(text)
+}
+
+func (m model) View() string {
+	if !m.ready {
+		return "\n  Initializing..."
+	}
+	var finalView string
+	if m.view == viewLogs {
+		finalView = fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
+	} else if m.view == viewYAML || m.view == viewDiff { // New case for YAML view
+		finalView = fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
+	} else if m.view == viewScaling {
+		var b strings.Builder
+		b.WriteString(m.details)
+		b.WriteString("\n\nScale replicas: " + m.textInput.View())
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewSetImage {
+		var b strings.Builder
+		b.WriteString(m.details)
+		b.WriteString("\n\nSet image (container=image:tag): " + m.textInput.View())
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewLogGrep {
+		var b strings.Builder
+		b.WriteString(m.details)
+		b.WriteString("\n\nGrep the last N log lines (N pattern): " + m.textInput.View())
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewNodeFilter {
+		var b strings.Builder
+		b.WriteString(m.renderPodsList())
+		b.WriteString("\n\nShow pods on node: " + m.textInput.View())
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewPortForward {
+		var b strings.Builder
+		b.WriteString(m.renderServicesList())
+		b.WriteString("\n\nForward local port to service port (local:port): " + m.textInput.View())
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewCreateNamespace {
+		var b strings.Builder
+		b.WriteString(m.renderNamespacesList())
+		b.WriteString("\n\nNew namespace: " + m.textInput.View())
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewDeleteNamespace {
+		var b strings.Builder
+		b.WriteString(m.styles.Error.Render(fmt.Sprintf("Deleting namespace %s removes every resource in it.", m.deletingNamespace)))
+		b.WriteString(fmt.Sprintf("\n\nType %q to confirm: %s", m.deletingNamespace, m.textInput.View()))
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else if m.view == viewConfirm {
+		var b strings.Builder
+		if backdrop := m.confirmBackdrop(); backdrop != "" {
+			b.WriteString(backdrop + "\n\n")
+		}
+		b.WriteString(fmt.Sprintf("%s (y/n)", m.confirmPrompt))
+		viewContent := b.String()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	} else {
+		viewContent := m.renderViewContent()
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+	}
+
+	if m.err != nil {
+		finalView = lipgloss.JoinVertical(lipgloss.Left, m.errorBanner(), finalView)
+	}
+
+	return m.terminalSeq + m.styles.Base.Render(finalView)
+}
+
+// renderViewContent renders the body of the current view, between the header
+// and the footer.
+func (m *model) renderViewContent() string {
+	if f, ok := m.forbidden[m.view]; ok {
+		return m.renderForbidden(f)
+	}
+	switch m.view {
+	case viewDetails:
+		return m.viewport.View()
+	case viewPods:
+		return m.renderPodsList()
+	case viewPVCs:
+		return m.renderPVCsList()
+	case viewPVs:
+		return m.renderPVsList()
+	case viewDeployments:
+		return m.renderDeploymentsList()
+	case viewStatefulSets:
+		return m.renderStatefulSetsList()
+	case viewDaemonSets:
+		return m.renderDaemonSetsList()
+	case viewServices:
+		return m.renderServicesList()
+	case viewNetworkPolicies:
+		return m.renderNetworkPoliciesList()
+	case viewEvents:
+		return m.renderEventsList()
+	case viewRoles:
+		return m.renderRolesList()
+	case viewRoleBindings:
+		return m.renderRoleBindingsList()
+	case viewResourceQuotas:
+		return m.renderResourceQuotasList()
+	case viewLimitRanges:
+		return m.renderLimitRangesList()
+	case viewPDBs:
+		return m.renderPDBsList()
+	case viewCronJobs:
+		return m.renderCronJobsList()
+	case viewEndpointSlices:
+		return m.renderEndpointSlicesList()
+	case viewCRDs:
+		return m.renderCRDsList()
+	case viewCustomResources:
+		return m.renderCustomResourcesList()
+	case viewNamespaces:
+		return m.renderNamespacesList()
+	case viewResourceMenu:
+		return m.renderResourceMenu()
+	case viewHelp:
+		return m.renderHelpView()
+	case viewDashboard: // New case
+		return m.renderDashboard()
+	case viewRolloutStatus:
+		return m.renderRolloutStatus()
+	case viewCanI:
+		return m.renderCanI()
+	case viewApply:
+		return m.renderApply()
+	case viewSearch:
+		return m.renderSearch()
+	case viewNodeMap:
+		return m.renderNodeMap()
+	case viewResourceCounts:
+		return m.renderResourceCounts()
+	default: // viewNodes
+		return m.renderNodesList()
+	}
+}
+
+// listLen returns the number of rows in the current list view, 0 for other views.
+func (m *model) listLen() int {
+	return m.listLenOf(m.view)
+}
+
+func (m *model) listLenOf(v viewState) int {
+	switch v {
+	case viewNodes:
+		return len(m.nodes)
+	case viewNodeMap:
+		return len(m.nodeMap)
+	case viewResourceCounts:
+		return len(m.resourceCounts)
+	case viewPods:
+		return len(m.pods)
+	case viewPVCs:
+		return len(m.pvcs)
+	case viewPVs:
+		return len(m.pvs)
+	case viewDeployments:
+		return len(m.deployments)
+	case viewStatefulSets:
+		return len(m.statefulsets)
+	case viewDaemonSets:
+		return len(m.daemonsets)
+	case viewServices:
+		return len(m.services)
+	case viewNetworkPolicies:
+		return len(m.netpols)
+	case viewEvents:
+		if m.eventGrouped {
+			return len(groupEvents(m.visibleEvents()))
+		}
+		return len(m.visibleEvents())
+	case viewRoles:
+		return len(m.roles)
+	case viewRoleBindings:
+		return len(m.roleBindings)
+	case viewResourceQuotas:
+		return len(m.resourceQuotas)
+	case viewLimitRanges:
+		return len(m.limitRanges)
+	case viewPDBs:
+		return len(m.pdbs)
+	case viewCronJobs:
+		return len(m.cronJobs)
+	case viewEndpointSlices:
+		return len(m.endpointSlices)
+	case viewCRDs:
+		return len(m.crds)
+	case viewCustomResources:
+		return len(m.customResources)
+	}
+	return 0
+}
+
+// listHeight returns how many list rows fit on screen. The table header and
+// its border, the Base style's vertical padding and the scroll indicator are
+// drawn inside the viewport height as well.
+func (m *model) listHeight() int {
+	h := m.viewport.Height - 5
+	if h < 1 {
+		h = 1
+	}
+	return h
+}
+
+// scrollToCursor moves the list window just enough to keep the cursor visible.
+func (m *model) scrollToCursor() {
+	h := m.listHeight()
+	if m.cursor < m.listOffset {
+		m.listOffset = m.cursor
+	} else if m.cursor >= m.listOffset+h {
+		m.listOffset = m.cursor - h + 1
+	}
+}
+
+// visibleRange returns the [start, end) indexes of the rows shown for a list of n rows.
+func (m *model) visibleRange(n int) (int, int) {
+	h := m.listHeight()
+	start := m.listOffset
+	if start > n-h {
+		start = n - h
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + h
+	if end > n {
+		end = n
+	}
+	return start, end
+}
+
+// listColumns holds the NAMESPACE and NAME widths of a list, sized to the
+// longest value so long names are neither cut short nor left to push the
+// rest of their row out of line.
+type listColumns struct {
+	namespaceWidth int // 0 when the list has no NAMESPACE column
+	nameWidth      int
+}
+
+// listColumns sizes the NAMESPACE and NAME columns of a list of n rows. Each
+// is capped to a share of the terminal width, and longer values are cut.
+// Namespaced lists only show NAMESPACE when all namespaces are shown.
+func (m *model) listColumns(n int, namespaced bool, item func(i int) (namespace, name string)) listColumns {
+	cols := listColumns{nameWidth: len("NAME")}
+	if namespaced && m.selectedNamespace == "" {
+		cols.namespaceWidth = m.columnWidth("NAMESPACE", n, func(i int) string {
+			namespace, _ := item(i)
+			return namespace
+		})
+	}
+	for i := range n {
+		_, name := item(i)
+		cols.nameWidth = max(cols.nameWidth, len(name))
+	}
+	// Printing to stdout has no terminal width to fit, so nothing is cut.
+	if m.viewport.Width > 0 {
+		cols.nameWidth = min(cols.nameWidth, max(20, m.viewport.Width*2/5))
+	}
+	return cols
+}
+
+// columnWidth sizes a column holding names other than the row's own, such as
+// a namespace or a referenced object, to its longest value across n rows. It
+// is capped to a fifth of the terminal width.
+func (m *model) columnWidth(header string, n int, value func(i int) string) int {
+	width := len(header)
+	for i := range n {
+		width = max(width, len(value(i)))
+	}
+	if m.viewport.Width > 0 {
+		width = min(width, max(10, m.viewport.Width/5))
+	}
+	return width
+}
+
+// namespace renders a NAMESPACE cell with its trailing separator, or nothing
+// when the list has no NAMESPACE column.
+func (c listColumns) namespace(namespace string) string {
+	if c.namespaceWidth == 0 {
+		return ""
+	}
+	return fitColumn(namespace, c.namespaceWidth) + " "
+}
+
+// name renders a NAME cell.
+func (c listColumns) name(name string) string { return fitColumn(name, c.nameWidth) }
+
+// fitColumn pads s to width, cutting it with "..." if it's longer.
+func fitColumn(s string, width int) string {
+	if len(s) > width {
+		s = s[:width-3] + "..."
+	}
+	return fmt.Sprintf("%-*s", width, s)
+}
+
+// maxWideColumnWidth caps a wide column so one long label set can't push the
+// rest off screen.
+const maxWideColumnWidth = 60
+
+// wideColumns are the extra columns a list shows in wide mode, like kubectl
+// get -o wide. Each is as wide as its longest cell across all rows, so
+// columns don't shift while scrolling.
+type wideColumns struct {
+	headers []string
+	rows    [][]string
+	widths  []int
+}
+
+// wideColumns builds the wide columns of a list of n rows, or none when wide
+// mode is off.
+func (m *model) wideColumns(n int, headers []string, cells func(i int) []string) wideColumns {
+	if !m.wideLists {
+		return wideColumns{}
+	}
+	w := wideColumns{headers: headers, rows: make([][]string, n), widths: make([]int, len(headers))}
+	for c, h := range headers {
+		w.widths[c] = len(h)
+	}
+	for i := range n {
+		w.rows[i] = cells(i)
+		for c, cell := range w.rows[i] {
+			w.widths[c] = min(max(w.widths[c], len(cell)), maxWideColumnWidth)
+		}
+	}
+	return w
+}
+
+func (w wideColumns) format(cells []string) string {
+	var b strings.Builder
+	for c, cell := range cells {
+		b.WriteString(" " + fitColumn(cell, w.widths[c]))
+	}
+	return b.String()
+}
+
+// header returns the column titles to append to the list header.
+func (w wideColumns) header() string { return w.format(w.headers) }
+
+// row returns the cells of row i to append to its line.
+func (w wideColumns) row(i int) string {
+	if w.rows == nil {
+		return ""
+	}
+	return w.format(w.rows[i])
+}
+
+// serviceExternalIPs returns the addresses a service is reachable at from
+// outside the cluster: its load balancer ingress and any external IPs.
+func serviceExternalIPs(s v1.Service) []string {
+	var ips []string
+	for _, ing := range s.Status.LoadBalancer.Ingress {
+		if ing.IP != "" {
+			ips = append(ips, ing.IP)
+		} else if ing.Hostname != "" {
+			ips = append(ips, ing.Hostname)
+		}
+	}
+	return append(ips, s.Spec.ExternalIPs...)
+}
+
+// formatLabels renders labels as sorted key=value pairs, like --show-labels.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "<none>"
+	}
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// templateContainers returns the container names and images of a pod
+// template, comma-separated, like kubectl's CONTAINERS and IMAGES columns.
+func templateContainers(spec v1.PodSpec) (names, images string) {
+	var n, i []string
+	for _, c := range spec.Containers {
+		n = append(n, c.Name)
+		i = append(i, c.Image)
+	}
+	return strings.Join(n, ","), strings.Join(i, ",")
+}
+
+// rowNumber renders the 1-based index gutter for row i of a list of n rows.
+func rowNumber(i, n int) string {
+	return fmt.Sprintf("%*d ", len(strconv.Itoa(n)), i+1)
+}
+
+// rowNumberPadding returns blank space matching the rowNumber gutter, for headers.
+func rowNumberPadding(n int) string {
+	return strings.Repeat(" ", len(strconv.Itoa(n))+1)
+}
+
+// scrollIndicator describes the visible window when a list doesn't fit on screen.
+func (m *model) scrollIndicator(start, end, n int) string {
+	if end-start >= n {
+		return ""
+	}
+	return m.styles.Muted.Render(fmt.Sprintf("  %d-%d of %d", start+1, end, n)) + "\n"
+}
+
+// yamlView returns the YAML content as displayed, optionally with line numbers.
+// m.yamlContent itself is never modified so it stays valid YAML (or JSON).
+func (m *model) yamlView() string {
+	if !m.yamlLineNumbers {
+		return m.yamlContent
+	}
+	return numberLines(m.yamlContent)
+}
+
+// numberLines prefixes each line of s with its right-aligned 1-based line number.
+func numberLines(s string) string {
+	lines := strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+	width := len(strconv.Itoa(len(lines)))
+	var b strings.Builder
+	for i, line := range lines {
+		b.WriteString(fmt.Sprintf("%*d  %s\n", width, i+1, line))
+	}
+	return b.String()
+}
+
+// setViewportContent loads content into the viewport, soft-wrapped to its width,
+// and keeps the unwrapped text so it can be re-wrapped when the terminal resizes.
+func (m *model) setViewportContent(content string) {
+	m.viewportContent = content
+	m.viewport.SetContent(wrapText(content, m.viewport.Width))
+	m.viewport.GotoTop()
+}
+
+// wrapText soft-wraps s to the given width. A non-positive width leaves s unchanged.
+func wrapText(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return lipgloss.NewStyle().Width(width).Render(s)
+}
+
+// errorBanner renders the current error as a single line that fits the terminal width.
+func (m model) errorBanner() string {
+	text := strings.SplitN(m.err.Error(), "\n", 2)[0]
+	return m.styles.Error.MaxWidth(m.viewport.Width).Render("Error: " + text)
+}
+
+// menuItems returns the resource types matching the typed filter, ignoring case.
+func (m model) menuItems() []string {
+	if m.menuFilter == "" {
+		return m.resourceTypes
+	}
+	var items []string
+	for _, t := range m.resourceTypes {
+		if strings.Contains(strings.ToLower(t), strings.ToLower(m.menuFilter)) {
+			items = append(items, t)
+		}
+	}
+	return items
+}
+
+func (m *model) renderResourceMenu() string {
+	var b strings.Builder
+	b.WriteString(m.styles.HeaderText.Render("Select Resource Type") + "\n")
+	if m.menuFilter != "" {
+		b.WriteString(m.styles.Muted.Render("Filter: ") + m.menuFilter + "\n")
+	}
+
+	items := m.menuItems()
+	if len(items) == 0 {
+		return b.String() + "No matching resource types."
+	}
+	start, end := m.visibleRange(len(items))
+	for i := start; i < end; i++ {
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		b.WriteString(style.Render(items[i]) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(items)))
+	return b.String()
+}
+
+func (m *model) renderNamespacesList() string {
+	var b strings.Builder
+
+	// Row 0 is the "All Namespaces" option, so rows are offset by one
+	start, end := m.visibleRange(len(m.namespaces) + 1)
+	for i := start; i < end; i++ {
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		if i == 0 {
+			b.WriteString(style.Render("[ All Namespaces ]") + "\n")
+			continue
+		}
+		name := m.namespaces[i-1].Name
+		marker := "  "
+		if m.isFavoriteNamespace(name) {
+			marker = "★ "
+		}
+		b.WriteString(style.Render(marker+name) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.namespaces)+1))
+	return b.String()
+}
+
+// renderForbidden explains a list refused by RBAC in place of its rows.
+func (m *model) renderForbidden(f forbiddenMsg) string {
+	var b strings.Builder
+	msg := f.Error()
+	b.WriteString(m.styles.Error.Render(strings.ToUpper(msg[:1])+msg[1:]+".") + "\n\n")
+	b.WriteString(m.styles.Muted.Render(f.err.Error()) + "\n\n")
+	b.WriteString("Other views and namespaces are still available.")
+	return b.String()
+}
+
+// eventObject names the object an event is about, e.g. "Pod/web-0".
+func eventObject(ref v1.ObjectReference) string {
+	return ref.Kind + "/" + ref.Name
+}
+
+func (m *model) renderEventsList() string {
+	var b strings.Builder
+	events := m.visibleEvents()
+	if len(events) == 0 {
+		return "No Events found."
+	}
+	if m.eventGrouped {
+		return m.renderEventGroups(groupEvents(events))
+	}
+
+	cols := m.listColumns(len(events), true, func(i int) (string, string) { return events[i].Namespace, "" })
+	objectWidth := m.columnWidth("OBJECT", len(events), func(i int) string { return eventObject(events[i].InvolvedObject) })
+	header := m.styles.Header.Render(rowNumberPadding(len(events)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%-"+"15s %-"+"10s %-"+"20s %s %s", "LAST SEEN", "TYPE", "REASON", fitColumn("OBJECT", objectWidth), "MESSAGE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(events))
+	for i := start; i < end; i++ {
+		e := events[i]
+		// Warnings stand out in red and normal events fade, so failures are
+		// easy to spot while following a deploy
+		severity := m.styles.Muted
+		if e.Type == v1.EventTypeWarning {
+			severity = m.styles.Error
+		}
+		style := m.styles.Row
+		if m.eventFollow {
+			style = severity
+		}
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+
+		ts := e.LastTimestamp.Time.Format("15:04:05")
+		msg := strings.Split(e.Message, "\n")[0] // First line only
+
+		line := fmt.Sprintf("%-"+"15s %s %-"+"20s %s %s", ts, severity.Render(fmt.Sprintf("%-"+"10s", e.Type)), e.Reason, fitColumn(eventObject(e.InvolvedObject), objectWidth), msg)
+		b.WriteString(style.Render(rowNumber(i, len(events))+cols.namespace(e.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(events)))
+	return b.String()
+}
+
+// renderEventGroups shows one row per involved object with its event count
+// and latest message, so an object with unusual events isn't buried under
+// the routine Pulled/Created/Started lines of a rollout.
+func (m *model) renderEventGroups(groups []eventGroup) string {
+	var b strings.Builder
+	cols := m.listColumns(len(groups), true, func(i int) (string, string) { return groups[i].events[0].Namespace, "" })
+	objectWidth := m.columnWidth("OBJECT", len(groups), func(i int) string { return eventObject(groups[i].object) })
+	header := m.styles.Header.Render(rowNumberPadding(len(groups)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%-"+"15s %-"+"10s %-"+"7s %s %s", "LAST SEEN", "TYPE", "COUNT", fitColumn("OBJECT", objectWidth), "LATEST MESSAGE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(groups))
+	for i := start; i < end; i++ {
+		g := groups[i]
+		latest := g.events[0]
+		severity, kind := m.styles.Muted, v1.EventTypeNormal
+		if g.hasWarning() {
+			severity, kind = m.styles.Error, v1.EventTypeWarning
+		}
+		style := m.styles.Row
+		if m.eventFollow {
+			style = severity
+		}
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+
+		ts := latest.LastTimestamp.Time.Format("15:04:05")
+		msg := strings.Split(latest.Message, "\n")[0]
+
+		line := fmt.Sprintf("%-"+"15s %s %-"+"7d %s %s", ts, severity.Render(fmt.Sprintf("%-"+"10s", kind)), g.count(), fitColumn(eventObject(g.object), objectWidth), msg)
+		b.WriteString(style.Render(rowNumber(i, len(groups))+cols.namespace(latest.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(groups)))
+	return b.String()
+}
+
+func (m *model) renderRolesList() string {
+	var b strings.Builder
+	if len(m.roles) == 0 {
+		return "No Roles found."
+	}
+
+	cols := m.listColumns(len(m.roles), true, func(i int) (string, string) { return m.roles[i].Namespace, m.roles[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.roles)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"10s %s", cols.name("NAME"), "RULES", "AGE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.roles))
+	for i := start; i < end; i++ {
+		r := m.roles[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		line := fmt.Sprintf("%s %-"+"10d %s", cols.name(r.Name), len(r.Rules), formatAge(r.CreationTimestamp))
+		b.WriteString(style.Render(rowNumber(i, len(m.roles))+cols.namespace(r.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.roles)))
+	return b.String()
+}
+
+// roleRefName names the role a binding grants, e.g. "ClusterRole/view".
+func roleRefName(ref rbacv1.RoleRef) string {
+	return ref.Kind + "/" + ref.Name
+}
+
+func (m *model) renderRoleBindingsList() string {
+	var b strings.Builder
+	if len(m.roleBindings) == 0 {
+		return "No RoleBindings found."
+	}
+
+	cols := m.listColumns(len(m.roleBindings), true, func(i int) (string, string) { return m.roleBindings[i].Namespace, m.roleBindings[i].Name })
+	roleWidth := m.columnWidth("ROLE", len(m.roleBindings), func(i int) string { return roleRefName(m.roleBindings[i].RoleRef) })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.roleBindings)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %s %-"+"10s %s", cols.name("NAME"), fitColumn("ROLE", roleWidth), "SUBJECTS", "AGE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.roleBindings))
+	for i := start; i < end; i++ {
+		rb := m.roleBindings[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		line := fmt.Sprintf("%s %s %-"+"10d %s", cols.name(rb.Name), fitColumn(roleRefName(rb.RoleRef), roleWidth), len(rb.Subjects), formatAge(rb.CreationTimestamp))
+		b.WriteString(style.Render(rowNumber(i, len(m.roleBindings))+cols.namespace(rb.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.roleBindings)))
+	return b.String()
+}
+
+func (m *model) renderResourceQuotasList() string {
+	var b strings.Builder
+	if len(m.resourceQuotas) == 0 {
+		return "No ResourceQuotas found."
+	}
+
+	cols := m.listColumns(len(m.resourceQuotas), true, func(i int) (string, string) { return m.resourceQuotas[i].Namespace, m.resourceQuotas[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.resourceQuotas)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"10s %s", cols.name("NAME"), "AGE", "USED/HARD"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.resourceQuotas))
+	for i := start; i < end; i++ {
+		q := m.resourceQuotas[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		var usage []string
+		for _, name := range sortedResourceNames(q.Status.Hard) {
+			used := q.Status.Used[name]
+			hard := q.Status.Hard[name]
+			usage = append(usage, fmt.Sprintf("%s: %s/%s", name, used.String(), hard.String()))
+		}
+		line := fmt.Sprintf("%s %-"+"10s %s", cols.name(q.Name), formatAge(q.CreationTimestamp), strings.Join(usage, ", "))
+		b.WriteString(style.Render(rowNumber(i, len(m.resourceQuotas))+cols.namespace(q.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.resourceQuotas)))
+	return b.String()
+}
+
+func (m *model) renderLimitRangesList() string {
+	var b strings.Builder
+	if len(m.limitRanges) == 0 {
+		return "No LimitRanges found."
+	}
+
+	cols := m.listColumns(len(m.limitRanges), true, func(i int) (string, string) { return m.limitRanges[i].Namespace, m.limitRanges[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.limitRanges)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"30s %s", cols.name("NAME"), "TYPES", "AGE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.limitRanges))
+	for i := start; i < end; i++ {
+		lr := m.limitRanges[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		var types []string
+		for _, item := range lr.Spec.Limits {
+			types = append(types, string(item.Type))
+		}
+		line := fmt.Sprintf("%s %-"+"30s %s", cols.name(lr.Name), strings.Join(types, ","), formatAge(lr.CreationTimestamp))
+		b.WriteString(style.Render(rowNumber(i, len(m.limitRanges))+cols.namespace(lr.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.limitRanges)))
+	return b.String()
+}
+
+func (m *model) renderPDBsList() string {
+	var b strings.Builder
+	if len(m.pdbs) == 0 {
+		return "No PodDisruptionBudgets found."
+	}
+
+	cols := m.listColumns(len(m.pdbs), true, func(i int) (string, string) { return m.pdbs[i].Namespace, m.pdbs[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.pdbs)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"15s %-"+"17s %-"+"22s %s", cols.name("NAME"), "MIN AVAILABLE", "MAX UNAVAILABLE", "ALLOWED DISRUPTIONS", "HEALTHY"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.pdbs))
+	for i := start; i < end; i++ {
+		p := m.pdbs[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		minAvailable, maxUnavailable := "N/A", "N/A"
+		if p.Spec.MinAvailable != nil {
+			minAvailable = p.Spec.MinAvailable.String()
+		}
+		if p.Spec.MaxUnavailable != nil {
+			maxUnavailable = p.Spec.MaxUnavailable.String()
+		}
+		allowedStyle := m.styles.Success
+		if p.Status.DisruptionsAllowed == 0 {
+			allowedStyle = m.styles.Warning
+		}
+		healthy := fmt.Sprintf("%d/%d", p.Status.CurrentHealthy, p.Status.DesiredHealthy)
+		line := fmt.Sprintf("%s %-"+"15s %-"+"17s %-"+"22s %s", cols.name(p.Name), minAvailable, maxUnavailable,
+			allowedStyle.Render(fmt.Sprintf("%d", p.Status.DisruptionsAllowed)), healthy)
+		b.WriteString(style.Render(rowNumber(i, len(m.pdbs))+cols.namespace(p.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.pdbs)))
+	return b.String()
+}
+
+func cronJobSuspended(c batchv1.CronJob) bool {
+	return c.Spec.Suspend != nil && *c.Spec.Suspend
+}
+
+func (m *model) renderCronJobsList() string {
+	var b strings.Builder
+	if len(m.cronJobs) == 0 {
+		return "No CronJobs found."
+	}
+
+	cols := m.listColumns(len(m.cronJobs), true, func(i int) (string, string) { return m.cronJobs[i].Namespace, m.cronJobs[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.cronJobs)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"20s %-"+"9s %-"+"8s %s", cols.name("NAME"), "SCHEDULE", "SUSPEND", "ACTIVE", "LAST SCHEDULE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.cronJobs))
+	for i := start; i < end; i++ {
+		c := m.cronJobs[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		suspend := m.styles.Success.Render(fmt.Sprintf("%-"+"9s", "False"))
+		if cronJobSuspended(c) {
+			suspend = m.styles.Warning.Render(fmt.Sprintf("%-"+"9s", "True"))
+		}
+		lastSchedule := "<none>"
+		if c.Status.LastScheduleTime != nil {
+			lastSchedule = formatAge(*c.Status.LastScheduleTime)
+		}
+		line := fmt.Sprintf("%s %-"+"20s %s %-"+"8d %s", cols.name(c.Name), c.Spec.Schedule, suspend, len(c.Status.Active), lastSchedule)
+		b.WriteString(style.Render(rowNumber(i, len(m.cronJobs))+cols.namespace(c.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.cronJobs)))
+	return b.String()
+}
+
+func (m *model) renderEndpointSlicesList() string {
+	var b strings.Builder
+	if len(m.endpointSlices) == 0 {
+		return "No EndpointSlices found."
+	}
+
+	cols := m.listColumns(len(m.endpointSlices), true, func(i int) (string, string) { return m.endpointSlices[i].Namespace, m.endpointSlices[i].Name })
+	serviceWidth := m.columnWidth("SERVICE", len(m.endpointSlices), func(i int) string { return m.endpointSlices[i].Labels[discoveryv1.LabelServiceName] })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.endpointSlices)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %s %-"+"12s %-"+"10s %s", cols.name("NAME"), fitColumn("SERVICE", serviceWidth), "ADDRESSTYPE", "READY", "PORTS"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.endpointSlices))
+	for i := start; i < end; i++ {
+		s := m.endpointSlices[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		ready := 0
+		for _, e := range s.Endpoints {
+			if e.Conditions.Ready == nil || *e.Conditions.Ready {
+				ready++
+			}
+		}
+		readyStyle := m.styles.Success
+		if ready == 0 {
+			readyStyle = m.styles.Error
+		} else if ready < len(s.Endpoints) {
+			readyStyle = m.styles.Warning
+		}
+		line := fmt.Sprintf("%s %s %-"+"12s %-"+"10s %s", cols.name(s.Name), fitColumn(s.Labels[discoveryv1.LabelServiceName], serviceWidth), s.AddressType,
+			readyStyle.Render(fmt.Sprintf("%d/%d", ready, len(s.Endpoints))), formatEndpointPorts(s.Ports))
+		b.WriteString(style.Render(rowNumber(i, len(m.endpointSlices))+cols.namespace(s.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.endpointSlices)))
+	return b.String()
+}
+
+func (m *model) renderCRDsList() string {
+	var b strings.Builder
+	if len(m.crds) == 0 {
+		return "No Custom Resource Definitions found."
+	}
+
+	cols := m.listColumns(len(m.crds), false, func(i int) (string, string) { return "", m.crds[i].name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.crds)) + fmt.Sprintf("%s %-"+"25s %-"+"10s %s", cols.name("NAME"), "KIND", "VERSION", "SCOPE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.crds))
+	for i := start; i < end; i++ {
+		crd := m.crds[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		scope := "Cluster"
+		if crd.namespaced {
+			scope = "Namespaced"
+		}
+		line := fmt.Sprintf("%s %-"+"25s %-"+"10s %s", cols.name(crd.name), crd.kind, crd.gvr.Version, scope)
+		b.WriteString(style.Render(rowNumber(i, len(m.crds))+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.crds)))
+	return b.String()
+}
+
+func (m *model) renderCustomResourcesList() string {
+	var b strings.Builder
+	if len(m.customResources) == 0 {
+		return fmt.Sprintf("No %s found.", m.selectedCRD.kind)
+	}
+
+	cols := m.listColumns(len(m.customResources), false, func(i int) (string, string) { return "", m.customResources[i].GetName() })
+	namespaceWidth := m.columnWidth("NAMESPACE", len(m.customResources), func(i int) string { return m.customResources[i].GetNamespace() })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.customResources)) + fmt.Sprintf("%s %s %s", cols.name("NAME"), fitColumn("NAMESPACE", namespaceWidth), "AGE"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.customResources))
+	for i := start; i < end; i++ {
+		cr := m.customResources[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		line := fmt.Sprintf("%s %s %s", cols.name(cr.GetName()), fitColumn(cr.GetNamespace(), namespaceWidth), formatAge(cr.GetCreationTimestamp()))
+		b.WriteString(style.Render(rowNumber(i, len(m.customResources))+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.customResources)))
+	return b.String()
+}
+
+func (m *model) renderNetworkPoliciesList() string {
+	var b strings.Builder
+	if len(m.netpols) == 0 {
+		return "No Network Policies found."
+	}
+
+	cols := m.listColumns(len(m.netpols), true, func(i int) (string, string) { return m.netpols[i].Namespace, m.netpols[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.netpols)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %s", cols.name("NAME"), "POD SELECTOR"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.netpols))
+	for i := start; i < end; i++ {
+		p := m.netpols[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
 		}
-		if m.view == viewLogs {
-			switch msg.String() {
-			case "esc", "backspace", "q":
-				m.view = viewDetails
-			default:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
+		selector, _ := metav1.LabelSelectorAsSelector(&p.Spec.PodSelector)
+		line := fmt.Sprintf("%s %s", cols.name(p.Name), selector.String())
+		b.WriteString(style.Render(rowNumber(i, len(m.netpols))+cols.namespace(p.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.netpols)))
+	return b.String()
+}
+
+func (m *model) renderNodesList() string {
+	var b strings.Builder
+	if len(m.nodes) == 0 {
+		return "Fetching nodes..."
+	}
+
+	wide := m.wideColumns(len(m.nodes), []string{"AGE", "VERSION", "INTERNAL-IP", "OS-IMAGE", "KERNEL-VERSION", "CONTAINER-RUNTIME"}, func(i int) []string {
+		n := m.nodes[i]
+		internalIP := "<none>"
+		for _, a := range n.Status.Addresses {
+			if a.Type == v1.NodeInternalIP {
+				internalIP = a.Address
 			}
-			return m, tea.Batch(cmds...)
 		}
-		if m.view == viewYAML { // New view for YAML
-			switch msg.String() {
-			case "esc", "backspace", "q":
-				m.view = viewDetails
-			default:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
-			}
-			return m, tea.Batch(cmds...)
+		info := n.Status.NodeInfo
+		return []string{formatAge(n.CreationTimestamp), info.KubeletVersion, internalIP, info.OSImage, info.KernelVersion, info.ContainerRuntimeVersion}
+	})
+	cols := m.listColumns(len(m.nodes), false, func(i int) (string, string) { return "", m.nodes[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.nodes)) + fmt.Sprintf("%s %-"+"15s %-"+"15s %-"+"20s %-"+"6s %-"+"6s %-"+"6s %-"+"9s %-"+"9s %-"+"6s", cols.name("NAME"), "STATUS", "ROLES", "CONDITIONS", "CPU%", "MEM%", "PODS", "CPU REQ%", "MEM REQ%", "TAINTS") + wide.header())
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.nodes))
+	for i := start; i < end; i++ {
+		node := m.nodes[i]
+		status := getNodeStatus(node)
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
 		}
-		if m.view == viewDetails {
-			switch msg.String() {
-			case "d":
-				if m.previousView == viewPods {
-					m.view = viewConfirmDelete
-					return m, nil
-				}
-			case "r":
-				if m.previousView == viewDeployments {
-					m.view = viewScaling
-					m.textInput.Focus()
-					m.textInput.SetValue(fmt.Sprintf("%d", *m.deployments[m.cursor].Spec.Replicas))
-					return m, nil
-				}
-			case "l":
-				if m.previousView == viewPods {
-					pod := m.pods[m.cursor]
-					return m, getLogs(m.clientset, pod.Namespace, pod.Name)
-				}
-			case "y": // New keybinding for YAML
-				var name, namespace, kind string
-				switch m.previousView {
-				case viewNodes:
-					name = m.nodes[m.cursor].Name
-					kind = "Node"
-				case viewPods:
-					name = m.pods[m.cursor].Name
-					namespace = m.pods[m.cursor].Namespace
-					kind = "Pod"
-				case viewPVCs:
-					name = m.pvcs[m.cursor].Name
-					namespace = m.pvcs[m.cursor].Namespace
-					kind = "PersistentVolumeClaim"
-				case viewPVs:
-					name = m.pvs[m.cursor].Name
-					kind = "PersistentVolume"
-				case viewDeployments:
-					name = m.deployments[m.cursor].Name
-					namespace = m.deployments[m.cursor].Namespace
-					kind = "Deployment"
-				case viewStatefulSets:
-					name = m.statefulsets[m.cursor].Name
-					namespace = m.statefulsets[m.cursor].Namespace
-					kind = "StatefulSet"
-				case viewDaemonSets:
-					name = m.daemonsets[m.cursor].Name
-					namespace = m.daemonsets[m.cursor].Namespace
-					kind = "DaemonSet"
-				case viewServices:
-					name = m.services[m.cursor].Name
-					namespace = m.services[m.cursor].Namespace
-					kind = "Service"
-				case viewNetworkPolicies:
-					name = m.netpols[m.cursor].Name
-					namespace = m.netpols[m.cursor].Namespace
-					kind = "NetworkPolicy"
-				case viewEvents:
-					name = m.events[m.cursor].Name
-					namespace = m.events[m.cursor].Namespace
-					kind = "Event"
-				case viewNamespaces:
-					// Namespaces don't have a specific YAML view in this context,
-					// or it's less common to view their YAML directly from a list.
-					// For now, we can skip or add later if needed.
-					return m, nil
-				default:
-					return m, nil
-				}
-				return m, getResourceYAML(m.clientset, namespace, name, kind)
-			case "esc", "backspace":
-				m.view = m.previousView
-			}
-			return m, nil
+
+		statusStyle := m.getStatusStyle(status)
+
+		metrics, hasMetrics := m.nodeMetrics[node.Name]
+		cpuPercent := "---"
+		memPercent := "---"
+		if hasMetrics {
+			cpuPercent = formatPercentage(metrics.Usage.Cpu().MilliValue(), node.Status.Capacity.Cpu().MilliValue()) + "%"
+			memPercent = formatPercentage(metrics.Usage.Memory().Value(), node.Status.Capacity.Memory().Value()) + "%"
 		}
-		if m.view == viewHelp {
-			switch msg.String() {
-			case "esc", "backspace", "q", "?":
-				m.view = m.previousView
-			}
-			return m, nil
+		podCount, cpuReqPercent, memReqPercent := "---", "---", "---"
+		if m.nodeAllocations != nil {
+			alloc := m.nodeAllocations[node.Name]
+			podCount = strconv.Itoa(alloc.pods)
+			cpuReqPercent = formatPercentage(alloc.cpuRequests.MilliValue(), node.Status.Allocatable.Cpu().MilliValue()) + "%"
+			memReqPercent = formatPercentage(alloc.memoryRequests.Value(), node.Status.Allocatable.Memory().Value()) + "%"
 		}
-		if m.view == viewNamespaces {
-			switch msg.String() {
-			case "enter":
-				if m.cursor == 0 {
-					m.selectedNamespace = "" // All namespaces
-				} else {
-					m.selectedNamespace = m.namespaces[m.cursor-1].Name
-				}
-				m.view = m.previousView
-				updatedModel, cmd := m.Update(tickMsg{})
-				return updatedModel, cmd
-			case "esc", "backspace", "N":
-				m.view = m.previousView
-			case "up":
-				if m.cursor > 0 {
-					m.cursor--
-				}
-			case "down":
-				if m.cursor < len(m.namespaces) {
-					m.cursor++
-				}
-			}
-			return m, nil
+		conditions := fmt.Sprintf("%-"+"20s", "-")
+		if pressure := nodePressureConditions(node); len(pressure) > 0 {
+			conditions = m.styles.Error.Render(fmt.Sprintf("%-"+"20s", strings.Join(pressure, ",")))
 		}
-		if m.view == viewResourceMenu {
-			switch msg.String() {
-			case "enter":
-				selectedResource := m.resourceTypes[m.cursor]
-				switch selectedResource {
-				case "Nodes":
-					m.view = viewNodes
-					return m, getNodes(m.clientset, m.metricsClientset)
-				case "Pods":
-					m.view = viewPods
-					return m, getPods(m.clientset, m.metricsClientset, m.selectedNamespace)
-				case "Deployments":
-					m.view = viewDeployments
-					return m, getDeployments(m.clientset, m.selectedNamespace)
-				case "StatefulSets":
-					m.view = viewStatefulSets
-					return m, getStatefulSets(m.clientset, m.selectedNamespace)
-				case "DaemonSets":
-					m.view = viewDaemonSets
-					return m, getDaemonSets(m.clientset, m.selectedNamespace)
-				case "Services":
-					m.view = viewServices
-					return m, getServices(m.clientset, m.selectedNamespace)
-				case "PVCs":
-					m.view = viewPVCs
-					return m, getPVCs(m.clientset, m.selectedNamespace)
-				case "PVs":
-					m.view = viewPVs
-					return m, getPVs(m.clientset)
-				case "Network Policies":
-					m.view = viewNetworkPolicies
-					return m, getNetworkPolicies(m.clientset, m.selectedNamespace)
-				case "Events":
-					m.view = viewEvents
-					return m, getEvents(m.clientset, m.selectedNamespace)
-				}
-			case "esc", "backspace", "r":
-				m.view = m.previousView
-			case "up":
-				if m.cursor > 0 {
-					m.cursor--
-				}
-			case "down":
-				if m.cursor < len(m.resourceTypes)-1 {
-					m.cursor++
-				}
-			}
-			return m, nil
+		line := fmt.Sprintf("%s %s %-"+"15s %s %-"+"6s %-"+"6s %-"+"6s %-"+"9s %-"+"9s %-"+"6d", cols.name(node.Name), statusStyle.Render(fmt.Sprintf("%-"+"15s", status)), getNodeRoles(node), conditions,
+			cpuPercent, memPercent, podCount, cpuReqPercent, memReqPercent, len(node.Spec.Taints)) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.nodes))+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.nodes)))
+	return b.String()
+}
+
+func (m *model) renderPodsList() string {
+	var b strings.Builder
+	if len(m.pods) == 0 {
+		return "No Pods found."
+	}
+
+	priorityWidth := len("PRIORITY")
+	if m.wideLists { // Only padded when wide columns follow
+		for _, pod := range m.pods {
+			priorityWidth = max(priorityWidth, len(formatPodPriority(pod)))
 		}
+	}
+	wide := m.wideColumns(len(m.pods), []string{"AGE", "IP", "NODE", "LABELS"}, func(i int) []string {
+		p := m.pods[i]
+		return []string{formatAge(p.CreationTimestamp), p.Status.PodIP, p.Spec.NodeName, formatLabels(p.Labels)}
+	})
+	cols := m.listColumns(len(m.pods), true, func(i int) (string, string) { return m.pods[i].Namespace, m.pods[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.pods)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"15s %-"+"10s %-"+"16s %-"+"6s %-"+"18s %-"+"6s %-"+"10s %-*s", cols.name("NAME"), "STATUS", "RESTARTS", "CPU USE/REQ", "CPU%", "MEM USE/REQ", "MEM%", "QOS", priorityWidth, "PRIORITY") + wide.header())
+	b.WriteString(header + "\n")
 
-		switch msg.String() {
-		case "?":
-			m.previousView = m.view
-			m.view = viewHelp
-			return m, nil
-		case "q", "ctrl+c":
-			return m, tea.Quit
-		case "r":
-			m.previousView = m.view
-			m.view = viewResourceMenu
-			m.cursor = 0 // Reset cursor for the new menu
-			return m, nil
-		case "N":
-			m.previousView = m.view
-			m.view = viewNamespaces
-			return m, getNamespaces(m.clientset)
-		case "D": // New keybinding for Dashboard
-			m.previousView = m.view
-			m.view = viewDashboard
-			return m, getDashboardMetrics(m.clientset, m.metricsClientset)
-		case "up":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-		case "down", "j":
-			listLen := 0
-			switch m.view {
-			case viewNodes:
-				listLen = len(m.nodes)
-			case viewPods:
-				listLen = len(m.pods)
-			case viewPVCs:
-				listLen = len(m.pvcs)
-			case viewPVs:
-				listLen = len(m.pvs)
-			case viewDeployments:
-				listLen = len(m.deployments)
-			case viewStatefulSets:
-				listLen = len(m.statefulsets)
-			case viewDaemonSets:
-				listLen = len(m.daemonsets)
-			case viewServices:
-				listLen = len(m.services)
-			case viewNetworkPolicies:
-				listLen = len(m.netpols)
-			case viewEvents:
-				listLen = len(m.events)
-			}
-			if m.cursor < listLen-1 {
-				m.cursor++
-			}
-		case "enter":
-			m.previousView = m.view
-			m.view = viewDetails
-			switch m.previousView {
-			case viewNodes:
-				node := m.nodes[m.cursor]
-				metrics, hasMetrics := m.nodeMetrics[node.Name]
-				m.details = m.formatNodeDetails(node, metrics, hasMetrics)
-			case viewPods:
-				pod := m.pods[m.cursor]
-				metrics, hasMetrics := m.podMetrics[pod.Name]
-				m.details = m.formatPodDetails(pod, metrics, hasMetrics)
-			case viewPVCs:
-				m.details = m.formatPVCDetails(m.pvcs[m.cursor])
-			case viewPVs:
-				m.details = m.formatPVDetails(m.pvs[m.cursor])
-			case viewDeployments:
-				m.details = m.formatDeploymentDetails(m.deployments[m.cursor])
-			case viewStatefulSets:
-				m.details = m.formatStatefulSetDetails(m.statefulsets[m.cursor])
-			case viewDaemonSets:
-				m.details = m.formatDaemonSetDetails(m.daemonsets[m.cursor])
-			case viewServices:
-				m.details = m.formatServiceDetails(m.services[m.cursor])
-			case viewNetworkPolicies:
-				m.details = m.formatNetworkPolicyDetails(m.netpols[m.cursor])
-			case viewEvents:
-				m.details = m.formatEventDetails(m.events[m.cursor])
-			}
-			return m, nil
+	start, end := m.visibleRange(len(m.pods))
+	for i := start; i < end; i++ {
+		pod := m.pods[i]
+		status := getPodStatus(pod)
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+
+		statusStyle := m.getStatusStyle(status)
+
+		cpuRequests := totalPodCPURequests(pod)
+		memRequests := totalPodMemoryRequests(pod)
+		var cpuUsage, memUsage *resource.Quantity
+		if metrics, hasMetrics := m.podMetrics[pod.Name]; hasMetrics {
+			cpuUsage = totalPodCPU(metrics)
+			memUsage = totalPodMemory(metrics)
 		}
+		cpuUseReq := m.formatCPU(cpuUsage) + "/" + m.formatCPU(cpuRequests)
+		memUseReq := m.formatMemory(memUsage) + "/" + m.formatMemory(memRequests)
+		cpuPercent := m.usageOfRequest(cpuUsage, cpuRequests, true)
+		memPercent := m.usageOfRequest(memUsage, memRequests, false)
+		restarts := fmt.Sprintf("%-"+"10d", podRestarts(pod))
+		if podRestarts(pod) > m.restartThreshold {
+			restarts = m.styles.Error.Render(restarts)
+		}
+		statusCell := statusStyle.Render(fmt.Sprintf("%-"+"15s", status))
+		if status != "OOMKilled" && len(oomKilledContainers(pod)) > 0 {
+			// Badge pods that look fine now but were OOM killed, e.g. "Running OOM"
+			statusCell = statusStyle.Render(status) + " " + m.styles.Error.Render("OOM") + strings.Repeat(" ", max(0, 15-len(status)-4))
+		}
+		line := fmt.Sprintf("%s %s %s %-"+"16s %s %-"+"18s %s %s %s", cols.name(pod.Name), statusCell, restarts, cpuUseReq, cpuPercent, memUseReq, memPercent, m.qosCell(pod), fmt.Sprintf("%-*s", priorityWidth, formatPodPriority(pod))) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.pods))+cols.namespace(pod.Namespace)+line) + "\n")
 	}
-	return m, tea.Batch(cmds...)
+	b.WriteString(m.scrollIndicator(start, end, len(m.pods)))
+	return b.String()
 }
 
-func (m model) headerView() string {
-	var title string
-	nsText := "all namespaces"
-	if m.selectedNamespace != "" {
-		nsText = m.selectedNamespace
+// usageOfRequest renders usage as a padded percentage of request, highlighting
+// pods using more than they asked for. It is "---" without metrics or requests.
+func (m *model) usageOfRequest(usage, request *resource.Quantity, milli bool) string {
+	if usage == nil || request.IsZero() {
+		return fmt.Sprintf("%-"+"6s", "---")
 	}
-
-	switch m.view {
-	case viewNodes:
-		title = "Nodes"
-	case viewPods:
-		title = fmt.Sprintf("Pods in %s", nsText)
-	case viewPVCs:
-		title = fmt.Sprintf("PVCs in %s", nsText)
-	case viewPVs:
-		title = "PersistentVolumes"
-	case viewDeployments:
-		title = fmt.Sprintf("Deployments in %s", nsText)
-	case viewStatefulSets:
-		title = fmt.Sprintf("StatefulSets in %s", nsText)
-	case viewDaemonSets:
-		title = fmt.Sprintf("DaemonSets in %s", nsText)
-	case viewServices:
-		title = fmt.Sprintf("Services in %s", nsText)
-	case viewNetworkPolicies:
-		title = fmt.Sprintf("Network Policies in %s", nsText)
-	case viewEvents:
-		title = fmt.Sprintf("Events in %s", nsText)
-	case viewNamespaces:
-		title = "Select Namespace"
-	case viewResourceMenu:
-		title = "Select Resource"
-	case viewHelp:
-		title = "Help"
-	case viewDetails:
-		title = "Details"
-	case viewLogs:
-		pod := m.pods[m.cursor]
-		title = fmt.Sprintf("Logs for %s", pod.Name)
-	case viewScaling:
-		d := m.deployments[m.cursor]
-		title = fmt.Sprintf("Scale Deployment: %s", d.Name)
-	case viewConfirmDelete:
-		p := m.pods[m.cursor]
-		title = fmt.Sprintf("Delete Pod: %s", p.Name)
-	case viewYAML:
-		title = "YAML Details"
-	case viewDashboard: // New case
-		title = "Cluster Dashboard"
+	use, req := usage.Value(), request.Value()
+	if milli {
+		use, req = usage.MilliValue(), request.MilliValue()
 	}
-	return m.styles.HeaderText.Render(title)
+	cell := fmt.Sprintf("%-"+"6s", formatPercentage(use, req)+"%")
+	if use > req {
+		return m.styles.Warning.Render(cell)
+	}
+	return cell
 }
 
-func (m model) footerView() string {
-	if m.view == viewHelp {
-		return m.styles.Muted.Render("(esc) back")
+func (m *model) renderPVCsList() string {
+	var b strings.Builder
+	if len(m.pvcs) == 0 {
+		return "No PVCs found."
 	}
 
-	help := "(q)uit | (r)esources | (D)ash | (N)s | (?) help"
+	wide := m.wideColumns(len(m.pvcs), []string{"AGE", "ACCESS MODES", "VOLUME MODE"}, func(i int) []string {
+		pvc := m.pvcs[i]
+		var modes []string
+		for _, mode := range pvc.Spec.AccessModes {
+			modes = append(modes, string(mode))
+		}
+		volumeMode := "Filesystem"
+		if pvc.Spec.VolumeMode != nil {
+			volumeMode = string(*pvc.Spec.VolumeMode)
+		}
+		return []string{formatAge(pvc.CreationTimestamp), strings.Join(modes, ","), volumeMode}
+	})
+	cols := m.listColumns(len(m.pvcs), true, func(i int) (string, string) { return m.pvcs[i].Namespace, m.pvcs[i].Name })
+	volumeWidth := m.columnWidth("VOLUME", len(m.pvcs), func(i int) string { return m.pvcs[i].Spec.VolumeName })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.pvcs)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"15s %-"+"10s %-"+"20s %-"+"15s %s", cols.name("NAME"), "STATUS", "CAPACITY", "USED", "STORAGECLASS", fitColumn("VOLUME", volumeWidth)) + wide.header())
+	b.WriteString(header + "\n")
 
-	if m.view == viewDetails {
-		baseHelp := "(esc) back"
-		switch m.previousView {
-		case viewPods:
-			baseHelp += " | (l)ogs | (d)elete | (y)aml"
-		case viewDeployments:
-			baseHelp += " | (r)eplicas | (y)aml"
-		default:
-			baseHelp += " | (y)aml"
+	start, end := m.visibleRange(len(m.pvcs))
+	for i := start; i < end; i++ {
+		pvc := m.pvcs[i]
+		status := string(pvc.Status.Phase)
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
 		}
-		help = baseHelp
-	}
-	if m.view == viewLogs {
-		help = "(esc) back to details"
-	}
-	if m.view == viewYAML {
-		help = "(esc) back to details"
-	}
-	if m.view == viewScaling {
-		help = "(enter) confirm | (esc) cancel"
+		statusStyle := m.getStatusStyle(status)
+		capacity := pvc.Status.Capacity[v1.ResourceStorage]
+		storageClass := "<none>"
+		if pvc.Spec.StorageClassName != nil {
+			storageClass = *pvc.Spec.StorageClassName
+		}
+		line := fmt.Sprintf("%s %-"+"15s %-"+"10s %s %-"+"15s %s", cols.name(pvc.Name), statusStyle.Render(status), capacity.String(), m.pvcUsed(pvc), storageClass, fitColumn(pvc.Spec.VolumeName, volumeWidth)) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.pvcs))+cols.namespace(pvc.Namespace)+line) + "\n")
 	}
-	if m.view == viewConfirmDelete {
-		help = "(y)es / (n)o"
+	b.WriteString(m.scrollIndicator(start, end, len(m.pvcs)))
+	return b.String()
+}
+
+// pvcUsed renders a PVC's used space and share of its filesystem, warning
+// from 80% and flagging an error from 90%.
+func (m *model) pvcUsed(pvc v1.PersistentVolumeClaim) string {
+	stats, ok := m.pvcUsage[pvc.Namespace+"/"+pvc.Name]
+	if !ok || stats.capacity == 0 {
+		return fmt.Sprintf("%-"+"20s", "---")
 	}
-	if m.view == viewResourceMenu {
-		help = "(enter) select | (esc) back"
+	cell := fmt.Sprintf("%-"+"20s", fmt.Sprintf("%s (%s%%)", formatBytes(stats.used), formatPercentage(stats.used, stats.capacity)))
+	switch pct := percentOf(stats.used, stats.capacity); {
+	case pct >= 90:
+		return m.styles.Error.Render(cell)
+	case pct >= 80:
+		return m.styles.Warning.Render(cell)
 	}
-	return m.styles.Muted.Render(help)
+	return cell
 }
 
-func (m model) View() string {
-	if !m.ready {
-		return "\n  Initializing..."
-	}
-	if m.err != nil {
-		errorMsg := m.styles.Error.Render(fmt.Sprintf("Error: %v", m.err))
-		help := m.styles.Muted.Render("\nIs the Kubernetes Metrics Server installed and running?")
-		return m.styles.Base.Render(lipgloss.JoinVertical(lipgloss.Left, errorMsg, help))
+func (m *model) renderPVsList() string {
+	var b strings.Builder
+	if len(m.pvs) == 0 {
+		return "No PVs found."
 	}
 
-	var finalView string
-	if m.view == viewLogs {
-		finalView = fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
-	} else if m.view == viewYAML { // New case for YAML view
-		m.viewport.SetContent(m.yamlContent)
-		finalView = fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
-	} else if m.view == viewScaling {
-		var b strings.Builder
-		b.WriteString(m.details)
-		b.WriteString("\n\nScale replicas: " + m.textInput.View())
-		viewContent := b.String()
-		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
-	} else if m.view == viewConfirmDelete {
-		var b strings.Builder
-		b.WriteString(m.details)
-		b.WriteString(fmt.Sprintf("\n\nAre you sure you want to delete this pod? (y/n)"))
-		viewContent := b.String()
-		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
-	} else {
-		var viewContent string
-		switch m.view {
-		case viewDetails:
-			viewContent = m.details
-		case viewYAML:
-			m.viewport.SetContent(m.yamlContent)
-			viewContent = m.viewport.View()
-		case viewPods:
-			viewContent = m.renderPodsList()
-		case viewPVCs:
-			viewContent = m.renderPVCsList()
-		case viewPVs:
-			viewContent = m.renderPVsList()
-		case viewDeployments:
-			viewContent = m.renderDeploymentsList()
-		case viewStatefulSets:
-			viewContent = m.renderStatefulSetsList()
-		case viewDaemonSets:
-			viewContent = m.renderDaemonSetsList()
-		case viewServices:
-			viewContent = m.renderServicesList()
-		case viewNetworkPolicies:
-			viewContent = m.renderNetworkPoliciesList()
-		case viewEvents:
-			viewContent = m.renderEventsList()
-		case viewNamespaces:
-			viewContent = m.renderNamespacesList()
-		case viewResourceMenu:
-			viewContent = m.renderResourceMenu()
-		case viewHelp:
-			viewContent = m.renderHelpView()
-		case viewDashboard: // New case
-			viewContent = m.renderDashboard()
-		default: // viewNodes
-			viewContent = m.renderNodesList()
+	cols := m.listColumns(len(m.pvs), false, func(i int) (string, string) { return "", m.pvs[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.pvs)) + fmt.Sprintf("%s %-"+"15s %-"+"10s %s", cols.name("NAME"), "STATUS", "CAPACITY", "CLAIM"))
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.pvs))
+	for i := start; i < end; i++ {
+		pv := m.pvs[i]
+		status := string(pv.Status.Phase)
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
 		}
-		finalView = lipgloss.JoinVertical(lipgloss.Left, m.headerView(), viewContent, m.footerView())
+		statusStyle := m.getStatusStyle(status)
+		capacity := pv.Spec.Capacity[v1.ResourceStorage]
+		claim := ""
+		if pv.Spec.ClaimRef != nil {
+			claim = pv.Spec.ClaimRef.Name
+		}
+		line := fmt.Sprintf("%s %-"+"15s %-"+"10s %s", cols.name(pv.Name), statusStyle.Render(status), capacity.String(), claim)
+		b.WriteString(style.Render(rowNumber(i, len(m.pvs))+line) + "\n")
 	}
-
-	return m.styles.Base.Render(finalView)
+	b.WriteString(m.scrollIndicator(start, end, len(m.pvs)))
+	return b.String()
 }
 
-func (m *model) renderHelpView() string {
+func (m *model) renderDeploymentsList() string {
 	var b strings.Builder
-	b.WriteString(m.styles.HeaderText.Render("Keybindings") + "\n\n")
-	b.WriteString("  Global:\n")
-	b.WriteString("    q, ctrl+c: Quit\n")
-	b.WriteString("    ?: Show this help view\n")
-	b.WriteString("    r: Open resource selection menu\n")
-	b.WriteString("    D: Show cluster dashboard\n")
-	b.WriteString("    N: Select namespace\n\n")
-	b.WriteString("  Navigation:\n")
-	b.WriteString("    up/down: Move cursor\n")
-	b.WriteString("    enter: Select / View details\n")
-	b.WriteString("    esc: Go back\n\n")
-	b.WriteString("  Details View (Pods):\n")
-	b.WriteString("    l: View logs\n")
-	b.WriteString("    d: Delete pod\n")
-	b.WriteString("    y: View YAML\n\n")
-	b.WriteString("  Details View (Deployments):\n")
-	b.WriteString("    r: Scale replicas\n")
-	b.WriteString("    y: View YAML\n\n")
-	b.WriteString("  Other Details Views:\n")
-	b.WriteString("    y: View YAML\n")
+	if len(m.deployments) == 0 {
+		return "No Deployments found."
+	}
+
+	wide := m.wideColumns(len(m.deployments), []string{"AGE", "CONTAINERS", "IMAGES", "SELECTOR"}, func(i int) []string {
+		d := m.deployments[i]
+		names, images := templateContainers(d.Spec.Template.Spec)
+		return []string{formatAge(d.CreationTimestamp), names, images, metav1.FormatLabelSelector(d.Spec.Selector)}
+	})
+	cols := m.listColumns(len(m.deployments), true, func(i int) (string, string) { return m.deployments[i].Namespace, m.deployments[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.deployments)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"10s %-"+"11s", cols.name("NAME"), "REPLICAS", "HEALTH") + wide.header())
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.deployments))
+	for i := start; i < end; i++ {
+		d := m.deployments[i]
+		style := m.styles.Row
+		if m.cursor == i {
+			style = m.styles.SelectedRow
+		}
+		replicas := fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.Status.Replicas)
+		health := deploymentHealth(&d)
+		healthStyle := m.styles.Success
+		switch health {
+		case "Progressing":
+			healthStyle = m.styles.Warning
+		case "Degraded":
+			healthStyle = m.styles.Error
+		}
+		line := fmt.Sprintf("%s %-"+"10s %s", cols.name(d.Name), replicas, healthStyle.Render(fmt.Sprintf("%-"+"11s", health))) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.deployments))+cols.namespace(d.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.deployments)))
 	return b.String()
 }
 
-func (m *model) renderResourceMenu() string {
+func (m *model) renderStatefulSetsList() string {
 	var b strings.Builder
-	b.WriteString(m.styles.HeaderText.Render("Select Resource Type") + "\n")
+	if len(m.statefulsets) == 0 {
+		return "No StatefulSets found."
+	}
+
+	wide := m.wideColumns(len(m.statefulsets), []string{"AGE", "CONTAINERS", "IMAGES"}, func(i int) []string {
+		s := m.statefulsets[i]
+		names, images := templateContainers(s.Spec.Template.Spec)
+		return []string{formatAge(s.CreationTimestamp), names, images}
+	})
+	cols := m.listColumns(len(m.statefulsets), true, func(i int) (string, string) { return m.statefulsets[i].Namespace, m.statefulsets[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.statefulsets)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"10s", cols.name("NAME"), "REPLICAS") + wide.header())
+	b.WriteString(header + "\n")
 
-	for i, resourceType := range m.resourceTypes {
+	start, end := m.visibleRange(len(m.statefulsets))
+	for i := start; i < end; i++ {
+		s := m.statefulsets[i]
 		style := m.styles.Row
 		if m.cursor == i {
 			style = m.styles.SelectedRow
 		}
-		b.WriteString(style.Render(resourceType) + "\n")
+		replicas := fmt.Sprintf("%d/%d", s.Status.ReadyReplicas, s.Status.Replicas)
+		line := fmt.Sprintf("%s %-"+"10s", cols.name(s.Name), replicas) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.statefulsets))+cols.namespace(s.Namespace)+line) + "\n")
 	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.statefulsets)))
 	return b.String()
 }
 
-func (m *model) renderNamespacesList() string {
+func (m *model) renderDaemonSetsList() string {
 	var b strings.Builder
-
-	// "All Namespaces" option
-	style := m.styles.Row
-	if m.cursor == 0 {
-		style = m.styles.SelectedRow
+	if len(m.daemonsets) == 0 {
+		return "No DaemonSets found."
 	}
-	b.WriteString(style.Render("[ All Namespaces ]") + "\n")
 
-	for i, ns := range m.namespaces {
+	wide := m.wideColumns(len(m.daemonsets), []string{"AGE", "CONTAINERS", "IMAGES", "NODE SELECTOR"}, func(i int) []string {
+		d := m.daemonsets[i]
+		names, images := templateContainers(d.Spec.Template.Spec)
+		return []string{formatAge(d.CreationTimestamp), names, images, formatLabels(d.Spec.Template.Spec.NodeSelector)}
+	})
+	cols := m.listColumns(len(m.daemonsets), true, func(i int) (string, string) { return m.daemonsets[i].Namespace, m.daemonsets[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.daemonsets)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"15s", cols.name("NAME"), "DESIRED/CURRENT") + wide.header())
+	b.WriteString(header + "\n")
+
+	start, end := m.visibleRange(len(m.daemonsets))
+	for i := start; i < end; i++ {
+		d := m.daemonsets[i]
 		style := m.styles.Row
-		if m.cursor == i+1 {
+		if m.cursor == i {
 			style = m.styles.SelectedRow
 		}
-		b.WriteString(style.Render(ns.Name) + "\n")
+		replicas := fmt.Sprintf("%d/%d", d.Status.DesiredNumberScheduled, d.Status.CurrentNumberScheduled)
+		line := fmt.Sprintf("%s %-"+"15s", cols.name(d.Name), replicas) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.daemonsets))+cols.namespace(d.Namespace)+line) + "\n")
 	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.daemonsets)))
 	return b.String()
 }
 
-func (m *model) renderEventsList() string {
+func (m *model) renderServicesList() string {
 	var b strings.Builder
-	if len(m.events) == 0 {
-		return "No Events found."
+	if len(m.services) == 0 {
+		return "No Services found."
 	}
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"15s %-"+"10s %-"+"20s %-"+"30s %s", "LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"))
+	ports := make([]string, len(m.services))
+	portsWidth := len("PORTS")
+	for i, s := range m.services {
+		var p []string
+		for _, port := range s.Spec.Ports {
+			p = append(p, fmt.Sprintf("%d:%d", port.Port, port.NodePort))
+		}
+		ports[i] = strings.Join(p, ",")
+		portsWidth = max(portsWidth, len(ports[i]))
+	}
+	if !m.wideLists {
+		portsWidth = 0 // Last column, no padding needed
+	}
+	wide := m.wideColumns(len(m.services), []string{"AGE", "EXTERNAL-IP", "SELECTOR"}, func(i int) []string {
+		s := m.services[i]
+		external := "<none>"
+		if ips := serviceExternalIPs(s); len(ips) > 0 {
+			external = strings.Join(ips, ",")
+		}
+		return []string{formatAge(s.CreationTimestamp), external, formatLabels(s.Spec.Selector)}
+	})
+	cols := m.listColumns(len(m.services), true, func(i int) (string, string) { return m.services[i].Namespace, m.services[i].Name })
+	header := m.styles.Header.Render(rowNumberPadding(len(m.services)) + cols.namespace("NAMESPACE") + fmt.Sprintf("%s %-"+"15s %-"+"15s %-*s", cols.name("NAME"), "TYPE", "CLUSTER-IP", portsWidth, "PORTS") + wide.header())
 	b.WriteString(header + "\n")
 
-	for i, e := range m.events {
+	start, end := m.visibleRange(len(m.services))
+	for i := start; i < end; i++ {
+		s := m.services[i]
 		style := m.styles.Row
 		if m.cursor == i {
 			style = m.styles.SelectedRow
 		}
+		line := fmt.Sprintf("%s %-"+"15s %-"+"15s %-*s", cols.name(s.Name), s.Spec.Type, s.Spec.ClusterIP, portsWidth, ports[i]) + wide.row(i)
+		b.WriteString(style.Render(rowNumber(i, len(m.services))+cols.namespace(s.Namespace)+line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.services)))
+	return b.String()
+}
 
-		ts := e.LastTimestamp.Time.Format("15:04:05")
-		obj := fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name)
-		msg := strings.Split(e.Message, "\n")[0] // First line only
-
-		typeStyle := m.styles.Success
-		if e.Type == "Warning" {
-			typeStyle = m.styles.Warning
+func (m *model) renderCanI() string {
+	var b strings.Builder
+	b.WriteString("Check whether an action is allowed, like `kubectl auth can-i`.\n")
+	b.WriteString(m.styles.Muted.Render("VERB RESOURCE[.GROUP][/SUBRESOURCE] [-n NAMESPACE] [--as NAMESPACE:SERVICEACCOUNT]") + "\n\n")
+	b.WriteString("can-i " + m.textInput.View() + "\n")
+
+	if r := m.canIResult; r != nil {
+		answer := m.styles.Error.Render("no")
+		if r.allowed {
+			answer = m.styles.Success.Render("yes")
+		}
+		b.WriteString(fmt.Sprintf("\n%s: %s\n", r.query, answer))
+		if r.reason != "" {
+			b.WriteString(m.styles.Muted.Render("Reason: "+r.reason) + "\n")
 		}
+	}
+	return b.String()
+}
 
-		line := fmt.Sprintf("%-"+"15s %-"+"10s %-"+"20s %-"+"30s %s", ts, typeStyle.Render(e.Type), e.Reason, obj, msg)
-		b.WriteString(style.Render(line) + "\n")
+func (m *model) renderApply() string {
+	var b strings.Builder
+	b.WriteString("Create or update the objects in a YAML or JSON file, like `kubectl apply --server-side -f`.\n")
+	b.WriteString(m.styles.Muted.Render("Multi-document files are split on ---. Objects without a namespace go to the selected one.") + "\n\n")
+	b.WriteString("File: " + m.textInput.View() + "\n")
+
+	if r := m.lastApply; r != nil {
+		b.WriteString(fmt.Sprintf("\n%s:\n", r.path))
+		if len(r.results) == 0 {
+			b.WriteString(m.styles.Muted.Render("  No objects found.") + "\n")
+		}
+		for _, res := range r.results {
+			if res.err != nil {
+				b.WriteString(m.styles.Error.Render(fmt.Sprintf("  %s: %v", res.object, res.err)) + "\n")
+				continue
+			}
+			b.WriteString(m.styles.Success.Render(fmt.Sprintf("  %s %s", res.object, res.action)) + "\n")
+		}
 	}
 	return b.String()
 }
 
-func (m *model) renderNetworkPoliciesList() string {
+func (m *model) renderResourceCounts() string {
 	var b strings.Builder
-	if len(m.netpols) == 0 {
-		return "No Network Policies found."
+	if len(m.resourceCounts) == 0 {
+		return "No resources found."
 	}
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"50s %s", "NAME", "POD SELECTOR"))
-	b.WriteString(header + "\n")
+	header := fmt.Sprintf("%-"+"40s", "NAMESPACE")
+	for _, k := range countedKinds {
+		header += fmt.Sprintf(" %-"+"9s", k.header)
+	}
+	b.WriteString(m.styles.Header.Render(header) + "\n")
 
-	for i, p := range m.netpols {
+	start, end := m.visibleRange(len(m.resourceCounts))
+	for i := start; i < end; i++ {
+		row := m.resourceCounts[i]
 		style := m.styles.Row
 		if m.cursor == i {
 			style = m.styles.SelectedRow
 		}
-		selector, _ := metav1.LabelSelectorAsSelector(&p.Spec.PodSelector)
-		line := fmt.Sprintf("%-"+"50s %s", p.Name, selector.String())
+		name := m.styles.HeaderText.Render(fmt.Sprintf("%-"+"40s", "TOTAL"))
+		if row.namespace != "" {
+			name = fmt.Sprintf("%-"+"40s", row.namespace)
+		}
+		line := name
+		for _, n := range row.counts {
+			count := "-" // Not allowed to list this kind
+			if n >= 0 {
+				count = strconv.Itoa(n)
+			}
+			line += fmt.Sprintf(" %-"+"9s", count)
+		}
 		b.WriteString(style.Render(line) + "\n")
 	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.resourceCounts)))
 	return b.String()
 }
 
-func (m *model) renderNodesList() string {
+func (m *model) renderNodeMap() string {
 	var b strings.Builder
-	if len(m.nodes) == 0 {
-		return "Fetching nodes..."
+	if len(m.nodeMap) == 0 {
+		return "No Nodes found."
 	}
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %-"+"10s", "NAME", "STATUS", "CPU%", "MEM%"))
+	header := m.styles.Header.Render(fmt.Sprintf("%-"+"60s %-"+"12s %-"+"16s %s", "NODE / POD", "STATUS", "CPU", "MEMORY"))
 	b.WriteString(header + "\n")
 
-	for i, node := range m.nodes {
-		status := getNodeStatus(node)
+	start, end := m.visibleRange(len(m.nodeMap))
+	for i := start; i < end; i++ {
+		row := m.nodeMap[i]
 		style := m.styles.Row
 		if m.cursor == i {
 			style = m.styles.SelectedRow
 		}
+		var line string
+		if row.pod == nil {
+			name := row.node
+			if name == "" {
+				name = "<not scheduled>"
+			}
+			cpu, mem := m.formatCPU(row.cpu), m.formatMemory(row.memory)
+			if row.cpu != nil && !row.allocatable.Cpu().IsZero() {
+				cpu += fmt.Sprintf(" (%s%%)", formatPercentage(row.cpu.MilliValue(), row.allocatable.Cpu().MilliValue()))
+				mem += fmt.Sprintf(" (%s%%)", formatPercentage(row.memory.Value(), row.allocatable.Memory().Value()))
+			}
+			line = m.styles.HeaderText.Render(fmt.Sprintf("%-"+"60s", name)) + fmt.Sprintf(" %-"+"12s %-"+"16s %s", fmt.Sprintf("%d pods", row.pods), cpu, mem)
+		} else {
+			branch := "├─ "
+			if i+1 == len(m.nodeMap) || m.nodeMap[i+1].pod == nil {
+				branch = "└─ "
+			}
+			status := getPodStatus(*row.pod)
+			name := branch + row.pod.Namespace + "/" + row.pod.Name
+			line = fmt.Sprintf("%-"+"60s %s %-"+"16s %s", name, m.getStatusStyle(status).Render(fmt.Sprintf("%-"+"12s", status)), m.formatCPU(row.cpu), m.formatMemory(row.memory))
+		}
+		b.WriteString(style.Render(line) + "\n")
+	}
+	b.WriteString(m.scrollIndicator(start, end, len(m.nodeMap)))
+	return b.String()
+}
 
-		statusStyle := m.getStatusStyle(status)
+func (m *model) renderSearch() string {
+	var b strings.Builder
+	b.WriteString("Find: " + m.textInput.View() + "\n\n")
+	if m.searchItems == nil {
+		return b.String() + m.styles.Muted.Render("Loading...")
+	}
+	results := m.searchResults()
+	if len(results) == 0 {
+		return b.String() + m.styles.Muted.Render("No matches.")
+	}
+	// Leave room for the input line; the list scrolls with the selection
+	rows := max(m.viewport.Height-3, 1)
+	start := max(m.searchCursor-rows+1, 0)
+	end := min(start+rows, len(results))
+	for i := start; i < end; i++ {
+		ref := results[i]
+		style := m.styles.Row
+		if i == m.searchCursor {
+			style = m.styles.SelectedRow
+		}
+		b.WriteString(style.Render(fmt.Sprintf("%-"+"12s %s", ref.kind, ref.name)))
+		if m.selectedNamespace == "" {
+			b.WriteString(m.styles.Muted.Render("  " + ref.namespace))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
 
-		metrics, hasMetrics := m.nodeMetrics[node.Name]
-		cpuPercent := "---"
-		memPercent := "---"
-		if hasMetrics {
-			cpuPercent = formatPercentage(metrics.Usage.Cpu().MilliValue(), node.Status.Capacity.Cpu().MilliValue()) + "%"
-			memPercent = formatPercentage(metrics.Usage.Memory().Value(), node.Status.Capacity.Memory().Value()) + "%"
+func (m *model) renderRolloutStatus() string {
+	var b strings.Builder
+	var desired int32
+	bar := func(label string, n int32) {
+		percent := 1.0
+		if desired > 0 {
+			percent = float64(n) / float64(desired)
 		}
-		line := fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %-"+"10s", node.Name, statusStyle.Render(status), cpuPercent, memPercent)
-		b.WriteString(style.Render(line) + "\n")
+		b.WriteString(fmt.Sprintf("  %-10s %3d/%-3d %s\n", label, n, desired, m.progress.ViewAs(percent)))
+	}
+
+	var status string
+	var done bool
+	switch {
+	case m.rolloutDeployment != nil:
+		d := m.rolloutDeployment
+		desired = 1
+		if d.Spec.Replicas != nil {
+			desired = *d.Spec.Replicas
+		}
+		bar("Updated:", d.Status.UpdatedReplicas)
+		bar("Ready:", d.Status.ReadyReplicas)
+		bar("Available:", d.Status.AvailableReplicas)
+		status, done = rolloutStatus(d)
+	case m.rolloutStatefulSet != nil:
+		s := m.rolloutStatefulSet
+		desired = 1
+		if s.Spec.Replicas != nil {
+			desired = *s.Spec.Replicas
+		}
+		bar("Updated:", s.Status.UpdatedReplicas)
+		bar("Current:", s.Status.CurrentReplicas)
+		bar("Ready:", s.Status.ReadyReplicas)
+		bar("Available:", s.Status.AvailableReplicas)
+		if partition := statefulSetPartition(s); partition > 0 {
+			b.WriteString(fmt.Sprintf("\n  Partition: %d (only pods with ordinal %d and up are updated: %d of %d)\n", partition, partition, max(desired-partition, 0), desired))
+		}
+		if s.Status.UpdateRevision != "" {
+			b.WriteString(fmt.Sprintf("  Revision:  %s -> %s\n", s.Status.CurrentRevision, s.Status.UpdateRevision))
+		}
+		status, done = statefulSetRolloutStatus(s)
+	case m.rolloutDaemonSet != nil:
+		d := m.rolloutDaemonSet
+		desired = d.Status.DesiredNumberScheduled
+		bar("Updated:", d.Status.UpdatedNumberScheduled)
+		bar("Ready:", d.Status.NumberReady)
+		bar("Available:", d.Status.NumberAvailable)
+		status, done = daemonSetRolloutStatus(d)
+	default:
+		return "Loading..."
+	}
+	statusStyle := m.styles.Warning
+	if done {
+		statusStyle = m.styles.Success
+	}
+	b.WriteString("\n" + statusStyle.Render(status) + "\n")
+	return b.String()
+}
+
+// formatControlPlaneHealth sums up the control-plane components, e.g.
+// "healthy (4 from control-plane pods)".
+func (m *model) formatControlPlaneHealth(h *clusterHealthMsg) string {
+	if len(h.components) == 0 {
+		return m.styles.Muted.Render("not visible (managed control plane, or no access)")
+	}
+	unhealthy := 0
+	for _, c := range h.components {
+		if !c.healthy {
+			unhealthy++
+		}
+	}
+	if unhealthy > 0 {
+		return m.styles.Error.Render(fmt.Sprintf("%d of %d unhealthy (from %s)", unhealthy, len(h.components), h.source))
+	}
+	return m.styles.Success.Render(fmt.Sprintf("healthy (%d from %s)", len(h.components), h.source))
+}
+
+// appendSample adds p to history, dropping the oldest samples beyond historySize.
+func appendSample(history []timeserieslinechart.TimePoint, p timeserieslinechart.TimePoint) []timeserieslinechart.TimePoint {
+	history = append(history, p)
+	if len(history) > historySize {
+		history = history[len(history)-historySize:]
+	}
+	return history
+}
+
+// dashboardChartLayout sizes the usage charts for a width x height viewport.
+// Wide terminals get the CPU and memory charts side by side; narrower ones
+// stack them, and short ones get flatter charts so the top-N lists stay in
+// view. ok is false when there's no room for a readable chart at all.
+func dashboardChartLayout(width, height int) (chartWidth, chartHeight int, sideBySide, ok bool) {
+	sideBySide = width >= sideBySideChartWidth
+	chartWidth = width - 4
+	if sideBySide {
+		chartWidth = (width - 6) / 2 // Two charts, indented, with a gap between them
+	}
+	chartHeight = 10
+	if height < 30 {
+		chartHeight = 6
+	}
+	return chartWidth, chartHeight, sideBySide, chartWidth >= minChartWidth
+}
+
+// renderUsageChart draws the cluster CPU and memory history as line charts.
+func (m *model) renderUsageChart() string {
+	if len(m.cpuHistory) < 2 {
+		return m.styles.Muted.Render("  Collecting usage history...") + "\n"
+	}
+	width, height, sideBySide, ok := dashboardChartLayout(m.viewport.Width, m.viewport.Height)
+	if !ok {
+		return m.styles.Muted.Render("  Widen the terminal to see usage history") + "\n"
+	}
+	chart := func(title string, history []timeserieslinechart.TimePoint, style lipgloss.Style) string {
+		first, last := history[0].Time, history[len(history)-1].Time
+		c := timeserieslinechart.New(width, height,
+			timeserieslinechart.WithTimeRange(first, last),
+			timeserieslinechart.WithYRange(0, 100),
+			timeserieslinechart.WithXLabelFormatter(timeserieslinechart.HourTimeLabelFormatter()),
+			timeserieslinechart.WithStyle(style),
+			timeserieslinechart.WithTimeSeries(history),
+		)
+		c.DrawBrailleAll()
+		return lipgloss.NewStyle().PaddingLeft(2).Render(style.Render("■ "+title) + "\n" + c.View())
+	}
+	cpu := chart("CPU %", m.cpuHistory, m.styles.Success)
+	memory := chart("Memory %", m.memoryHistory, m.styles.Warning)
+	if sideBySide {
+		return lipgloss.JoinHorizontal(lipgloss.Top, cpu, memory) + "\n"
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, cpu, memory) + "\n"
+}
+
+func (m *model) renderDashboard() string {
+	var b strings.Builder
+
+	if h := m.clusterHealth; h != nil {
+		b.WriteString(m.styles.HeaderText.Render("Cluster") + "\n")
+		b.WriteString(fmt.Sprintf("  Version:       %s\n", h.version))
+		b.WriteString("  Control plane: " + m.formatControlPlaneHealth(h) + "\n")
+		for _, c := range h.components {
+			line := fmt.Sprintf("    %-"+"25s %s", c.name, c.detail)
+			if !c.healthy {
+				line = m.styles.Error.Render(line)
+			}
+			b.WriteString(line + "\n")
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(m.styles.HeaderText.Render("Cluster Usage Trend") + "\n")
+	b.WriteString(m.renderUsageChart())
+	b.WriteString("\n")
+
+	usageTitle := "Cluster-wide Resource Usage"
+	if ns := m.dashboardNamespace(); ns != "" {
+		usageTitle = fmt.Sprintf("Resource Usage in %s (of cluster capacity)", ns)
+	}
+	b.WriteString(m.styles.HeaderText.Render(usageTitle) + "\n")
+	t := m.clusterTotals
+	b.WriteString(fmt.Sprintf("  CPU used:         %s\n", m.cpuAgainst(t.cpuUsage, t.cpuCapacity)))
+	b.WriteString(fmt.Sprintf("  CPU requested:    %s\n", m.cpuAgainst(t.cpuRequests, t.cpuCapacity)))
+	b.WriteString(fmt.Sprintf("  Memory used:      %s\n", m.memoryAgainst(t.memoryUsage, t.memoryCapacity)))
+	b.WriteString(fmt.Sprintf("  Memory requested: %s\n", m.memoryAgainst(t.memoryRequests, t.memoryCapacity)))
+	b.WriteString("\n")
+
+	b.WriteString(m.styles.HeaderText.Render(fmt.Sprintf("Top %d Pods by CPU Usage", m.topN)) + "\n")
+	if len(m.topPodsByCPU) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, p := range m.topPodsByCPU {
+		b.WriteString(fmt.Sprintf("  - %s/%s\n", p.Namespace, p.Name))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.styles.HeaderText.Render(fmt.Sprintf("Top %d Pods by Memory Usage", m.topN)) + "\n")
+	if len(m.topPodsByMemory) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, p := range m.topPodsByMemory {
+		b.WriteString(fmt.Sprintf("  - %s/%s\n", p.Namespace, p.Name))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.styles.HeaderText.Render(fmt.Sprintf("Top %d Nodes by CPU Usage", m.topN)) + "\n")
+	if len(m.topNodesByCPU) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, n := range m.topNodesByCPU {
+		b.WriteString(fmt.Sprintf("  - %s\n", n.Name))
+	}
+	b.WriteString("\n")
+
+	b.WriteString(m.styles.HeaderText.Render(fmt.Sprintf("Top %d Nodes by Memory Usage", m.topN)) + "\n")
+	if len(m.topNodesByMemory) == 0 {
+		b.WriteString("  (none)\n")
 	}
+	for _, n := range m.topNodesByMemory {
+		b.WriteString(fmt.Sprintf("  - %s\n", n.Name))
+	}
+	b.WriteString("\n")
+
+	return b.String()
+}
+
+func (m *model) formatEventDetails(e v1.Event) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Message:\t%s\n", e.Message))
+	b.WriteString(fmt.Sprintf("Source:\t\t%s, %s\n", e.Source.Component, e.Source.Host))
+	b.WriteString(fmt.Sprintf("Object:\t\t%s/%s\n", e.InvolvedObject.Kind, e.InvolvedObject.Name))
+	b.WriteString(fmt.Sprintf("Count:\t\t%d\n", e.Count))
+	b.WriteString(fmt.Sprintf("First Seen:\t%s\n", e.FirstTimestamp.Time.Format(time.RFC1123)))
+	b.WriteString(fmt.Sprintf("Last Seen:\t%s\n", e.LastTimestamp.Time.Format(time.RFC1123)))
 	return b.String()
 }
 
-func (m *model) renderPodsList() string {
+func (m *model) formatEventGroupDetails(g eventGroup) string {
 	var b strings.Builder
-	if len(m.pods) == 0 {
-		return "No Pods found."
+	b.WriteString(fmt.Sprintf("Object:\t\t%s/%s\n", g.object.Kind, g.object.Name))
+	if g.object.Namespace != "" {
+		b.WriteString(fmt.Sprintf("Namespace:\t%s\n", g.object.Namespace))
 	}
+	b.WriteString(fmt.Sprintf("Events:\t\t%d\n", g.count()))
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %-"+"10s", "NAME", "STATUS", "CPU%", "MEM%"))
-	b.WriteString(header + "\n")
-
-	for i, pod := range m.pods {
-		status := string(pod.Status.Phase)
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
+	b.WriteString("\n" + m.styles.HeaderText.Render("Events") + "\n")
+	for _, e := range g.events {
+		severity := m.styles.Muted
+		if e.Type == v1.EventTypeWarning {
+			severity = m.styles.Error
 		}
+		b.WriteString(fmt.Sprintf("  %s  %s  %-"+"20s x%-"+"4d %s\n", e.LastTimestamp.Time.Format("15:04:05"), severity.Render(fmt.Sprintf("%-"+"7s", e.Type)), e.Reason, max(e.Count, 1), strings.Split(e.Message, "\n")[0]))
+	}
+	return b.String()
+}
 
-		statusStyle := m.getStatusStyle(status)
+func (m *model) formatRoleDetails(r rbacv1.Role) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", r.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", r.Namespace))
 
-		cpuPercent := "---"
-		memPercent := "---"
-		metrics, hasMetrics := m.podMetrics[pod.Name]
-		if hasMetrics {
-			cpuRequests := totalPodCPURequests(pod)
-			memRequests := totalPodMemoryRequests(pod)
-			cpuUsage := totalPodCPU(metrics)
-			memUsage := totalPodMemory(metrics)
+	b.WriteString("\n" + m.styles.HeaderText.Render("Rules") + "\n")
+	b.WriteString(formatPolicyRules(r.Rules))
 
-			if cpuRequests.MilliValue() > 0 {
-				cpuPercent = formatPercentage(cpuUsage.MilliValue(), cpuRequests.MilliValue()) + "%"
-			}
-			if memRequests.Value() > 0 {
-				memPercent = formatPercentage(memUsage.Value(), memRequests.Value()) + "%"
-			}
-		}
-		line := fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %-"+"10s", pod.Name, statusStyle.Render(status), cpuPercent, memPercent)
-		b.WriteString(style.Render(line) + "\n")
-	}
 	return b.String()
 }
 
-func (m *model) renderPVCsList() string {
+func (m *model) formatRoleBindingDetails(rb rbacv1.RoleBinding) string {
 	var b strings.Builder
-	if len(m.pvcs) == 0 {
-		return "No PVCs found."
-	}
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", rb.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", rb.Namespace))
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %s", "NAME", "STATUS", "CAPACITY", "VOLUME"))
-	b.WriteString(header + "\n")
+	b.WriteString("\n" + m.styles.HeaderText.Render("Role") + "\n")
+	b.WriteString(fmt.Sprintf("  Kind:\t%s\n", rb.RoleRef.Kind))
+	b.WriteString(fmt.Sprintf("  Name:\t%s\n", rb.RoleRef.Name))
 
-	for i, pvc := range m.pvcs {
-		status := string(pvc.Status.Phase)
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
+	b.WriteString("\n" + m.styles.HeaderText.Render("Subjects") + "\n")
+	if len(rb.Subjects) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, s := range rb.Subjects {
+		b.WriteString(fmt.Sprintf("  - Kind:\t%s\n", s.Kind))
+		b.WriteString(fmt.Sprintf("    Name:\t%s\n", s.Name))
+		if s.Namespace != "" {
+			b.WriteString(fmt.Sprintf("    Namespace:\t%s\n", s.Namespace))
 		}
-		statusStyle := m.getStatusStyle(status)
-		capacity := pvc.Status.Capacity[v1.ResourceStorage]
-		line := fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %s", pvc.Name, statusStyle.Render(status), capacity.String(), pvc.Spec.VolumeName)
-		b.WriteString(style.Render(line) + "\n")
 	}
+
 	return b.String()
 }
 
-func (m *model) renderPVsList() string {
+// formatPolicyRules lists RBAC rules the way `kubectl describe role` groups them.
+func formatPolicyRules(rules []rbacv1.PolicyRule) string {
 	var b strings.Builder
-	if len(m.pvs) == 0 {
-		return "No PVs found."
+	if len(rules) == 0 {
+		b.WriteString("  (none)\n")
 	}
-
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %s", "NAME", "STATUS", "CAPACITY", "CLAIM"))
-	b.WriteString(header + "\n")
-
-	for i, pv := range m.pvs {
-		status := string(pv.Status.Phase)
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
+	for _, r := range rules {
+		groups := make([]string, len(r.APIGroups))
+		for i, g := range r.APIGroups {
+			if g == "" {
+				g = `""`
+			}
+			groups[i] = g
 		}
-		statusStyle := m.getStatusStyle(status)
-		capacity := pv.Spec.Capacity[v1.ResourceStorage]
-		claim := ""
-		if pv.Spec.ClaimRef != nil {
-			claim = pv.Spec.ClaimRef.Name
+		b.WriteString(fmt.Sprintf("  - API Groups:\t%s\n", strings.Join(groups, ", ")))
+		if len(r.Resources) > 0 {
+			b.WriteString(fmt.Sprintf("    Resources:\t%s\n", strings.Join(r.Resources, ", ")))
 		}
-		line := fmt.Sprintf("%-"+"40s %-"+"15s %-"+"10s %s", pv.Name, statusStyle.Render(status), capacity.String(), claim)
-		b.WriteString(style.Render(line) + "\n")
+		if len(r.ResourceNames) > 0 {
+			b.WriteString(fmt.Sprintf("    Resource Names:\t%s\n", strings.Join(r.ResourceNames, ", ")))
+		}
+		if len(r.NonResourceURLs) > 0 {
+			b.WriteString(fmt.Sprintf("    Non-Resource URLs:\t%s\n", strings.Join(r.NonResourceURLs, ", ")))
+		}
+		b.WriteString(fmt.Sprintf("    Verbs:\t%s\n", strings.Join(r.Verbs, ", ")))
 	}
 	return b.String()
 }
 
-func (m *model) renderDeploymentsList() string {
+func (m *model) formatResourceQuotaDetails(q v1.ResourceQuota) string {
 	var b strings.Builder
-	if len(m.deployments) == 0 {
-		return "No Deployments found."
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", q.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", q.Namespace))
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Resource Usage") + "\n")
+	b.WriteString(fmt.Sprintf("  %-"+"30s %-"+"12s %-"+"12s %s\n", "RESOURCE", "USED", "HARD", "USED%"))
+	for _, name := range sortedResourceNames(q.Status.Hard) {
+		used := q.Status.Used[name]
+		hard := q.Status.Hard[name]
+		percent := formatPercentage(used.MilliValue(), hard.MilliValue())
+		style := m.styles.Success
+		if used.Cmp(hard) >= 0 {
+			style = m.styles.Error
+		} else if percentOf(used.MilliValue(), hard.MilliValue()) >= 80 {
+			style = m.styles.Warning
+		}
+		b.WriteString(fmt.Sprintf("  %-"+"30s %-"+"12s %-"+"12s %s\n", name, used.String(), hard.String(), style.Render(percent+"%")))
 	}
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"10s", "NAME", "REPLICAS"))
-	b.WriteString(header + "\n")
+	return b.String()
+}
 
-	for i, d := range m.deployments {
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
+func (m *model) formatLimitRangeDetails(lr v1.LimitRange) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", lr.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", lr.Namespace))
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Limits") + "\n")
+	b.WriteString(fmt.Sprintf("  %-"+"12s %-"+"20s %-"+"10s %-"+"10s %-"+"16s %s\n", "TYPE", "RESOURCE", "MIN", "MAX", "DEFAULT REQUEST", "DEFAULT LIMIT"))
+	for _, item := range lr.Spec.Limits {
+		var names []v1.ResourceName
+		seen := map[v1.ResourceName]bool{}
+		for _, list := range []v1.ResourceList{item.Min, item.Max, item.DefaultRequest, item.Default} {
+			for _, name := range sortedResourceNames(list) {
+				if !seen[name] {
+					seen[name] = true
+					names = append(names, name)
+				}
+			}
+		}
+		sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+		for _, name := range names {
+			b.WriteString(fmt.Sprintf("  %-"+"12s %-"+"20s %-"+"10s %-"+"10s %-"+"16s %s\n", item.Type, name,
+				quantityOrDash(item.Min, name), quantityOrDash(item.Max, name),
+				quantityOrDash(item.DefaultRequest, name), quantityOrDash(item.Default, name)))
 		}
-		replicas := fmt.Sprintf("%d/%d", d.Status.ReadyReplicas, d.Status.Replicas)
-		line := fmt.Sprintf("%-"+"40s %-"+"10s", d.Name, replicas)
-		b.WriteString(style.Render(line) + "\n")
 	}
+
 	return b.String()
 }
 
-func (m *model) renderStatefulSetsList() string {
-	var b strings.Builder
-	if len(m.statefulsets) == 0 {
-		return "No StatefulSets found."
+// sortedResourceNames returns the resource names in list in alphabetical order.
+func sortedResourceNames(list v1.ResourceList) []v1.ResourceName {
+	names := make([]v1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
 	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"10s", "NAME", "REPLICAS"))
-	b.WriteString(header + "\n")
-
-	for i, s := range m.statefulsets {
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
-		}
-		replicas := fmt.Sprintf("%d/%d", s.Status.ReadyReplicas, s.Status.Replicas)
-		line := fmt.Sprintf("%-"+"40s %-"+"10s", s.Name, replicas)
-		b.WriteString(style.Render(line) + "\n")
+// quantityOrDash returns the quantity for name in list, or "-" when it is unset.
+func quantityOrDash(list v1.ResourceList, name v1.ResourceName) string {
+	if q, ok := list[name]; ok {
+		return q.String()
 	}
-	return b.String()
+	return "-"
 }
 
-func (m *model) renderDaemonSetsList() string {
+func (m *model) formatPDBDetails(p policyv1.PodDisruptionBudget) string {
 	var b strings.Builder
-	if len(m.daemonsets) == 0 {
-		return "No DaemonSets found."
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", p.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", p.Namespace))
+	if p.Spec.MinAvailable != nil {
+		b.WriteString(fmt.Sprintf("Min Available:\t%s\n", p.Spec.MinAvailable.String()))
 	}
+	if p.Spec.MaxUnavailable != nil {
+		b.WriteString(fmt.Sprintf("Max Unavailable:\t%s\n", p.Spec.MaxUnavailable.String()))
+	}
+	selector, _ := metav1.LabelSelectorAsSelector(p.Spec.Selector)
+	b.WriteString(fmt.Sprintf("Selector:\t%s\n", selector.String()))
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"10s", "NAME", "DESIRED/CURRENT"))
-	b.WriteString(header + "\n")
+	b.WriteString("\n" + m.styles.HeaderText.Render("Status") + "\n")
+	b.WriteString(fmt.Sprintf("  Allowed Disruptions:\t%d\n", p.Status.DisruptionsAllowed))
+	b.WriteString(fmt.Sprintf("  Current Healthy:\t%d\n", p.Status.CurrentHealthy))
+	b.WriteString(fmt.Sprintf("  Desired Healthy:\t%d\n", p.Status.DesiredHealthy))
+	b.WriteString(fmt.Sprintf("  Expected Pods:\t%d\n", p.Status.ExpectedPods))
 
-	for i, d := range m.daemonsets {
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
-		}
-		replicas := fmt.Sprintf("%d/%d", d.Status.DesiredNumberScheduled, d.Status.CurrentNumberScheduled)
-		line := fmt.Sprintf("%-"+"40s %-"+"10s", d.Name, replicas)
-		b.WriteString(style.Render(line) + "\n")
-	}
 	return b.String()
 }
 
-func (m *model) renderServicesList() string {
+func (m *model) formatCronJobDetails(c batchv1.CronJob) string {
 	var b strings.Builder
-	if len(m.services) == 0 {
-		return "No Services found."
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", c.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", c.Namespace))
+	b.WriteString(fmt.Sprintf("Schedule:\t%s\n", c.Spec.Schedule))
+	if c.Spec.TimeZone != nil {
+		b.WriteString(fmt.Sprintf("Time Zone:\t%s\n", *c.Spec.TimeZone))
 	}
+	b.WriteString(fmt.Sprintf("Suspend:\t%t\n", cronJobSuspended(c)))
+	b.WriteString(fmt.Sprintf("Concurrency:\t%s\n", c.Spec.ConcurrencyPolicy))
 
-	header := m.styles.Header.Render(fmt.Sprintf("%-"+"40s %-"+"15s %-"+"15s %s", "NAME", "TYPE", "CLUSTER-IP", "PORTS"))
-	b.WriteString(header + "\n")
-
-	for i, s := range m.services {
-		style := m.styles.Row
-		if m.cursor == i {
-			style = m.styles.SelectedRow
-		}
-		var ports []string
-		for _, p := range s.Spec.Ports {
-			ports = append(ports, fmt.Sprintf("%d:%d", p.Port, p.NodePort))
-		}
-		line := fmt.Sprintf("%-"+"40s %-"+"15s %-"+"15s %s", s.Name, s.Spec.Type, s.Spec.ClusterIP, strings.Join(ports, ","))
-		b.WriteString(style.Render(line) + "\n")
+	b.WriteString("\n" + m.styles.HeaderText.Render("Status") + "\n")
+	b.WriteString(fmt.Sprintf("  Active Jobs:\t%d\n", len(c.Status.Active)))
+	for _, job := range c.Status.Active {
+		b.WriteString(fmt.Sprintf("    - %s\n", job.Name))
+	}
+	if c.Status.LastScheduleTime != nil {
+		b.WriteString(fmt.Sprintf("  Last Schedule:\t%s ago\n", formatAge(*c.Status.LastScheduleTime)))
 	}
+	if c.Status.LastSuccessfulTime != nil {
+		b.WriteString(fmt.Sprintf("  Last Success:\t%s ago\n", formatAge(*c.Status.LastSuccessfulTime)))
+	}
+
 	return b.String()
 }
 
-func (m *model) renderDashboard() string {
+func (m *model) formatEndpointSliceDetails(s discoveryv1.EndpointSlice) string {
 	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", s.Name))
+	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", s.Namespace))
+	b.WriteString(fmt.Sprintf("Service:\t%s\n", s.Labels[discoveryv1.LabelServiceName]))
+	b.WriteString(fmt.Sprintf("Address Type:\t%s\n", s.AddressType))
+	b.WriteString(fmt.Sprintf("Ports:\t\t%s\n", formatEndpointPorts(s.Ports)))
 
-	b.WriteString(m.styles.HeaderText.Render("Cluster-wide Resource Usage") + "\n")
-	b.WriteString(fmt.Sprintf("  CPU: %s\n", m.clusterCPUUsage))
-	b.WriteString(fmt.Sprintf("  Memory: %s\n", m.clusterMemoryUsage))
-	b.WriteString("\n")
-
-	b.WriteString(m.styles.HeaderText.Render("Top 5 Pods by CPU Usage") + "\n")
-	if len(m.topPodsByCPU) == 0 {
+	b.WriteString("\n" + m.styles.HeaderText.Render("Endpoints") + "\n")
+	if len(s.Endpoints) == 0 {
 		b.WriteString("  (none)\n")
 	}
-	for _, p := range m.topPodsByCPU {
-		b.WriteString(fmt.Sprintf("  - %s/%s\n", p.Namespace, p.Name))
+	for _, e := range s.Endpoints {
+		b.WriteString(fmt.Sprintf("  - Addresses:\t%s\n", strings.Join(e.Addresses, ", ")))
+		b.WriteString(fmt.Sprintf("    Conditions:\tready=%s serving=%s terminating=%s\n",
+			m.formatCondition(e.Conditions.Ready, true), m.formatCondition(e.Conditions.Serving, true), m.formatCondition(e.Conditions.Terminating, false)))
+		if e.TargetRef != nil {
+			b.WriteString(fmt.Sprintf("    Target:\t%s/%s\n", e.TargetRef.Kind, e.TargetRef.Name))
+		}
+		if e.NodeName != nil {
+			b.WriteString(fmt.Sprintf("    Node:\t%s\n", *e.NodeName))
+		}
 	}
-	b.WriteString("\n")
 
-	b.WriteString(m.styles.HeaderText.Render("Top 5 Pods by Memory Usage") + "\n")
-	if len(m.topPodsByMemory) == 0 {
-		b.WriteString("  (none)\n")
+	return b.String()
+}
+
+// formatCondition renders an optional endpoint condition, colored by whether
+// its value is the healthy one. Unset conditions are shown as unknown.
+func (m *model) formatCondition(c *bool, healthy bool) string {
+	if c == nil {
+		return m.styles.Muted.Render("unknown")
 	}
-	for _, p := range m.topPodsByMemory {
-		b.WriteString(fmt.Sprintf("  - %s/%s\n", p.Namespace, p.Name))
+	style := m.styles.Success
+	if *c != healthy {
+		style = m.styles.Error
 	}
-	b.WriteString("\n")
+	return style.Render(strconv.FormatBool(*c))
+}
 
-	b.WriteString(m.styles.HeaderText.Render("Top 5 Nodes by CPU Usage") + "\n")
-	if len(m.topNodesByCPU) == 0 {
-		b.WriteString("  (none)\n")
+// formatEndpointPorts renders EndpointSlice ports as name:port/protocol.
+func formatEndpointPorts(ports []discoveryv1.EndpointPort) string {
+	var parts []string
+	for _, p := range ports {
+		var part string
+		if p.Name != nil && *p.Name != "" {
+			part = *p.Name + ":"
+		}
+		if p.Port != nil {
+			part += strconv.Itoa(int(*p.Port))
+		}
+		if p.Protocol != nil {
+			part += "/" + string(*p.Protocol)
+		}
+		parts = append(parts, part)
 	}
-	for _, n := range m.topNodesByCPU {
-		b.WriteString(fmt.Sprintf("  - %s\n", n.Name))
+	if len(parts) == 0 {
+		return "<none>"
 	}
-	b.WriteString("\n")
+	return strings.Join(parts, ",")
+}
 
-	b.WriteString(m.styles.HeaderText.Render("Top 5 Nodes by Memory Usage") + "\n")
-	if len(m.topNodesByMemory) == 0 {
+func (m *model) formatCustomResourceDetails(cr unstructured.Unstructured) string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", cr.GetName()))
+	if cr.GetNamespace() != "" {
+		b.WriteString(fmt.Sprintf("Namespace:\t%s\n", cr.GetNamespace()))
+	}
+	b.WriteString(fmt.Sprintf("Kind:\t\t%s\n", cr.GetKind()))
+	b.WriteString(fmt.Sprintf("API Version:\t%s\n", cr.GetAPIVersion()))
+	b.WriteString(fmt.Sprintf("Age:\t\t%s\n", formatAge(cr.GetCreationTimestamp())))
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Labels") + "\n")
+	if len(cr.GetLabels()) == 0 {
 		b.WriteString("  (none)\n")
 	}
-	for _, n := range m.topNodesByMemory {
-		b.WriteString(fmt.Sprintf("  - %s\n", n.Name))
+	keys := make([]string, 0, len(cr.GetLabels()))
+	for k := range cr.GetLabels() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		b.WriteString(fmt.Sprintf("  %s=%s\n", k, cr.GetLabels()[k]))
 	}
-	b.WriteString("\n")
 
 	return b.String()
 }
 
-func (m *model) formatEventDetails(e v1.Event) string {
+// formatEventsSection renders events as an "Events" section for a details view,
+// similar to the tail of `kubectl describe`.
+func (m *model) formatEventsSection(events []v1.Event) string {
 	var b strings.Builder
-	b.WriteString(fmt.Sprintf("Message:\t%s\n", e.Message))
-	b.WriteString(fmt.Sprintf("Source:\t\t%s, %s\n", e.Source.Component, e.Source.Host))
-	b.WriteString(fmt.Sprintf("Object:\t\t%s/%s\n", e.InvolvedObject.Kind, e.InvolvedObject.Name))
-	b.WriteString(fmt.Sprintf("Count:\t\t%d\n", e.Count))
-	b.WriteString(fmt.Sprintf("First Seen:\t%s\n", e.FirstTimestamp.Time.Format(time.RFC1123)))
-	b.WriteString(fmt.Sprintf("Last Seen:\t%s\n", e.LastTimestamp.Time.Format(time.RFC1123)))
+	b.WriteString("\n" + m.styles.HeaderText.Render("Events") + "\n")
+	if len(events) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, e := range events {
+		typeStyle := m.styles.Success
+		if e.Type == "Warning" {
+			typeStyle = m.styles.Warning
+		}
+		b.WriteString(fmt.Sprintf("  %-"+"10s %-"+"8s %-"+"25s %s\n",
+			formatAge(e.LastTimestamp), typeStyle.Render(e.Type), e.Reason, strings.Split(e.Message, "\n")[0]))
+	}
 	return b.String()
 }
 
@@ -1446,16 +7149,26 @@ func (m *model) formatNodeDetails(node v1.Node, metrics v1beta1.NodeMetrics, has
 	b.WriteString(fmt.Sprintf("Roles:\t%s\n", getNodeRoles(node)))
 	b.WriteString(fmt.Sprintf("Creation Timestamp:\t%s\n", node.CreationTimestamp.Format(time.RFC1123)))
 
-	if hasMetrics {
-		b.WriteString("\n" + m.styles.HeaderText.Render("Resource Usage") + "\n")
-		b.WriteString(fmt.Sprintf("  CPU:\t%s / %s (%s%%)\n",
-			formatMilliCPU(metrics.Usage.Cpu()),
-			formatMilliCPU(node.Status.Capacity.Cpu()),
-			formatPercentage(metrics.Usage.Cpu().MilliValue(), node.Status.Capacity.Cpu().MilliValue())))
-		b.WriteString(fmt.Sprintf("  Memory:\t%s / %s (%s%%)\n",
-			formatMiBMemory(metrics.Usage.Memory()),
-			formatMiBMemory(node.Status.Capacity.Memory()),
-			formatPercentage(metrics.Usage.Memory().Value(), node.Status.Capacity.Memory().Value())))
+	b.WriteString("\n" + m.styles.HeaderText.Render("Pressure (of allocatable, live)") + "\n")
+	b.WriteString(m.formatNodePressure(node, metrics, hasMetrics))
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Conditions") + "\n")
+	for _, c := range node.Status.Conditions {
+		// Ready is healthy when true; the pressure conditions are healthy when false
+		healthy := (c.Type == v1.NodeReady) == (c.Status == v1.ConditionTrue)
+		style := m.styles.Success
+		if !healthy {
+			style = m.styles.Error
+		}
+		b.WriteString(fmt.Sprintf("  %s:\t%s\t%s\n", c.Type, style.Render(string(c.Status)), c.Reason))
+	}
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Taints") + "\n")
+	if len(node.Spec.Taints) == 0 {
+		b.WriteString("  (none)\n")
+	}
+	for _, t := range node.Spec.Taints {
+		b.WriteString(fmt.Sprintf("  %s\n", t.ToString()))
 	}
 
 	b.WriteString("\n" + m.styles.HeaderText.Render("System Info") + "\n")
@@ -1469,13 +7182,80 @@ func (m *model) formatNodeDetails(node v1.Node, metrics v1beta1.NodeMetrics, has
 	return b.String()
 }
 
+// refreshNodeDetails re-renders the node details in place, keeping the scroll
+// position, after new metrics or the node's events arrive.
+func (m *model) refreshNodeDetails() {
+	node := m.nodes[m.cursor]
+	metrics, hasMetrics := m.nodeMetrics[node.Name]
+	m.details = m.formatNodeDetails(node, metrics, hasMetrics)
+	if m.nodeEvents != nil {
+		m.details += m.formatEventsSection(m.nodeEvents)
+	}
+	offset := m.viewport.YOffset
+	m.setViewportContent(m.detailsView())
+	m.viewport.SetYOffset(offset)
+}
+
+// formatNodePressure puts a node's usage and its pods' requests side by side
+// against what it can allocate. Usage above requests means pods are bursting;
+// requests near allocatable mean the scheduler will soon stop placing pods,
+// however idle the node looks.
+func (m *model) formatNodePressure(node v1.Node, metrics v1beta1.NodeMetrics, hasMetrics bool) string {
+	var b strings.Builder
+	alloc, hasAllocations := m.nodeAllocations[node.Name], m.nodeAllocations != nil
+	row := func(label, amount string, val, total int64) {
+		pct := percentOf(val, total)
+		cell := fmt.Sprintf("%-"+"12s %4s%%", amount, formatPercentage(val, total))
+		switch {
+		case pct >= 90:
+			cell = m.styles.Error.Render(cell)
+		case pct >= 80:
+			cell = m.styles.Warning.Render(cell)
+		}
+		b.WriteString(fmt.Sprintf("  %-"+"8s %-"+"11s %s %s\n", "", label, cell, m.progress.ViewAs(min(pct/100, 1))))
+	}
+
+	cpu, memory := node.Status.Allocatable.Cpu(), node.Status.Allocatable.Memory()
+	b.WriteString(fmt.Sprintf("  %-"+"8s %-"+"11s %s\n", "CPU", "allocatable", m.formatCPU(cpu)))
+	if hasMetrics {
+		row("used", m.formatCPU(metrics.Usage.Cpu()), metrics.Usage.Cpu().MilliValue(), cpu.MilliValue())
+	}
+	if hasAllocations {
+		row("requested", m.formatCPU(&alloc.cpuRequests), alloc.cpuRequests.MilliValue(), cpu.MilliValue())
+	}
+
+	b.WriteString(fmt.Sprintf("  %-"+"8s %-"+"11s %s\n", "Memory", "allocatable", m.formatMemory(memory)))
+	if hasMetrics {
+		row("used", m.formatMemory(metrics.Usage.Memory()), metrics.Usage.Memory().Value(), memory.Value())
+	}
+	if hasAllocations {
+		row("requested", m.formatMemory(&alloc.memoryRequests), alloc.memoryRequests.Value(), memory.Value())
+	}
+
+	if hasAllocations {
+		b.WriteString(fmt.Sprintf("  %-"+"8s %-"+"11s %d / %d\n", "Pods", "scheduled", alloc.pods, node.Status.Allocatable.Pods().Value()))
+	}
+	if !hasMetrics {
+		b.WriteString(m.styles.Muted.Render("  (no usage: metrics-server isn't reporting this node)") + "\n")
+	}
+	if !hasAllocations {
+		b.WriteString(m.styles.Muted.Render("  (no requests: pods in every namespace couldn't be listed)") + "\n")
+	}
+	return b.String()
+}
+
 func (m *model) formatPodDetails(pod v1.Pod, metrics v1beta1.PodMetrics, hasMetrics bool) string {
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("Name:\t%s\n", pod.Name))
 	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", pod.Namespace))
-	b.WriteString(fmt.Sprintf("Status:\t%s\n", m.getStatusStyle(string(pod.Status.Phase)).Render(string(pod.Status.Phase))))
+	b.WriteString(fmt.Sprintf("Status:\t%s\n", m.getStatusStyle(getPodStatus(pod)).Render(getPodStatus(pod))))
+	for _, cs := range oomKilledContainers(pod) {
+		b.WriteString(m.styles.Error.Render("OOMKilled:\t"+m.formatOOMKill(pod, cs, metrics, hasMetrics)) + "\n")
+	}
 	b.WriteString(fmt.Sprintf("Pod IP:\t%s\n", pod.Status.PodIP))
 	b.WriteString(fmt.Sprintf("Node:\t%s\n", pod.Spec.NodeName))
+	b.WriteString(fmt.Sprintf("QoS Class:\t%s\n", podQOSClass(pod)))
+	b.WriteString(fmt.Sprintf("Priority:\t%s\n", formatPodPriority(pod)))
 	b.WriteString(fmt.Sprintf("Creation Timestamp:\t%s\n", pod.CreationTimestamp.Format(time.RFC1123)))
 
 	if hasMetrics {
@@ -1492,37 +7272,253 @@ func (m *model) formatPodDetails(pod v1.Pod, metrics v1beta1.PodMetrics, hasMetr
 		if cpuRequests.MilliValue() > 0 {
 			cpuReqPercent = formatPercentage(cpuUsage.MilliValue(), cpuRequests.MilliValue()) + "%"
 		}
-		memReqPercent := "---"
-		if memRequests.Value() > 0 {
-			memReqPercent = formatPercentage(memUsage.Value(), memRequests.Value()) + "%"
+		memReqPercent := "---"
+		if memRequests.Value() > 0 {
+			memReqPercent = formatPercentage(memUsage.Value(), memRequests.Value()) + "%"
+		}
+
+		cpuLimPercent := "---"
+		if cpuLimits.MilliValue() > 0 {
+			cpuLimPercent = formatPercentage(cpuUsage.MilliValue(), cpuLimits.MilliValue()) + "%"
+		}
+		memLimPercent := "---"
+		if memLimits.Value() > 0 {
+			memLimPercent = formatPercentage(memUsage.Value(), memLimits.Value()) + "%"
+		}
+
+		b.WriteString(fmt.Sprintf("  CPU Usage:\t%s (Requests: %s, Limits: %s)\n",
+			m.formatCPU(cpuUsage), cpuReqPercent, cpuLimPercent))
+		b.WriteString(fmt.Sprintf("  Memory Usage:\t%s (Requests: %s, Limits: %s)\n",
+			m.formatMemory(memUsage), memReqPercent, memLimPercent))
+	}
+
+	// Init containers come first: one that keeps failing is why a pod is stuck in Init:0/1
+	if len(pod.Spec.InitContainers) > 0 {
+		b.WriteString("\n" + m.styles.HeaderText.Render("Init Containers") + "\n")
+		for _, c := range pod.Spec.InitContainers {
+			b.WriteString(m.formatContainer(c, findContainerStatus(pod.Status.InitContainerStatuses, c.Name)))
+		}
+	}
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Containers") + "\n")
+	for _, c := range pod.Spec.Containers {
+		b.WriteString(m.formatContainer(c, findContainerStatus(pod.Status.ContainerStatuses, c.Name)))
+	}
+
+	if len(pod.Spec.EphemeralContainers) > 0 {
+		b.WriteString("\n" + m.styles.HeaderText.Render("Ephemeral Containers") + "\n")
+		for _, c := range pod.Spec.EphemeralContainers {
+			b.WriteString(m.formatContainer(v1.Container(c.EphemeralContainerCommon), findContainerStatus(pod.Status.EphemeralContainerStatuses, c.Name)))
+		}
+	}
+
+	if len(pod.Spec.Volumes) > 0 {
+		b.WriteString("\n" + m.styles.HeaderText.Render("Volumes") + "\n")
+		mounts := volumeMounts(pod)
+		for _, v := range pod.Spec.Volumes {
+			b.WriteString(fmt.Sprintf("  - %s:\t%s\n", v.Name, formatVolumeSource(v)))
+			if len(mounts[v.Name]) == 0 {
+				b.WriteString(m.styles.Muted.Render("      not mounted") + "\n")
+			}
+			for _, mount := range mounts[v.Name] {
+				b.WriteString(fmt.Sprintf("      mounted at %s\n", mount))
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// formatOOMKill says when the container was OOM killed and compares its memory
+// limit with what it uses now. Without a limit the kill came from the node
+// running out of memory rather than the container's own cgroup.
+func (m *model) formatOOMKill(pod v1.Pod, cs v1.ContainerStatus, metrics v1beta1.PodMetrics, hasMetrics bool) string {
+	t := oomTermination(cs)
+	s := "container " + cs.Name
+	if !t.FinishedAt.IsZero() {
+		s += fmt.Sprintf(" %s ago", formatAge(t.FinishedAt))
+	}
+	var limit *resource.Quantity
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if mem, ok := c.Resources.Limits[v1.ResourceMemory]; c.Name == cs.Name && ok {
+			limit = &mem
+		}
+	}
+	if limit == nil {
+		s += ", no memory limit (killed when the node ran out of memory)"
+	} else {
+		s += ", limit " + m.formatMemory(limit)
+	}
+	if hasMetrics {
+		for _, c := range metrics.Containers {
+			if mem, ok := c.Usage[v1.ResourceMemory]; c.Name == cs.Name && ok {
+				s += ", using " + m.formatMemory(&mem) + " now"
+				if limit != nil && limit.Value() > 0 {
+					s += " (" + formatPercentage(mem.Value(), limit.Value()) + "%)"
+				}
+			}
+		}
+	}
+	return s
+}
+
+// formatContainer describes one container of a pod; cs is nil until the
+// kubelet reports a status for it.
+func (m *model) formatContainer(c v1.Container, cs *v1.ContainerStatus) string {
+	var b strings.Builder
+	ready := cs != nil && cs.Ready
+	readyStyle := m.styles.Muted
+	if ready {
+		readyStyle = m.styles.Success
+	}
+	b.WriteString(fmt.Sprintf("  - Name:\t%s\n", c.Name))
+	b.WriteString(fmt.Sprintf("    Image:\t%s\n", c.Image))
+	b.WriteString(fmt.Sprintf("    Ready:\t%s\n", readyStyle.Render(fmt.Sprintf("%t", ready))))
+	if cs != nil {
+		b.WriteString(fmt.Sprintf("    Restarts:\t%d\n", cs.RestartCount))
+		b.WriteString(m.formatContainerState("State", cs.State))
+		if cs.LastTerminationState.Terminated != nil {
+			b.WriteString(m.formatContainerState("Last State", cs.LastTerminationState))
+		}
+	}
+	if len(c.Env) > 0 || len(c.EnvFrom) > 0 {
+		b.WriteString("    Environment:\n")
+		for _, e := range c.Env {
+			b.WriteString(fmt.Sprintf("      %s:\t%s\n", e.Name, formatEnvValue(e)))
+		}
+		for _, from := range c.EnvFrom {
+			b.WriteString(fmt.Sprintf("      %s\n", formatEnvFrom(from)))
 		}
+	}
+	return b.String()
+}
 
-		cpuLimPercent := "---"
-		if cpuLimits.MilliValue() > 0 {
-			cpuLimPercent = formatPercentage(cpuUsage.MilliValue(), cpuLimits.MilliValue()) + "%"
+// volumeMounts maps each volume name to the "container:path" places it is
+// mounted, marking read-only mounts.
+func volumeMounts(pod v1.Pod) map[string][]string {
+	mounts := make(map[string][]string)
+	add := func(containers []v1.Container) {
+		for _, c := range containers {
+			for _, vm := range c.VolumeMounts {
+				mount := fmt.Sprintf("%s:%s", c.Name, vm.MountPath)
+				if vm.SubPath != "" {
+					mount += fmt.Sprintf(" (subPath %s)", vm.SubPath)
+				}
+				if vm.ReadOnly {
+					mount += " (ro)"
+				}
+				mounts[vm.Name] = append(mounts[vm.Name], mount)
+			}
 		}
-		memLimPercent := "---"
-		if memLimits.Value() > 0 {
-			memLimPercent = formatPercentage(memUsage.Value(), memLimits.Value()) + "%"
+	}
+	add(pod.Spec.InitContainers)
+	add(pod.Spec.Containers)
+	return mounts
+}
+
+// formatVolumeSource names a volume's type and where its data comes from.
+func formatVolumeSource(v v1.Volume) string {
+	switch s := v.VolumeSource; {
+	case s.ConfigMap != nil:
+		return "ConfigMap " + s.ConfigMap.Name
+	case s.Secret != nil:
+		return "Secret " + s.Secret.SecretName
+	case s.PersistentVolumeClaim != nil:
+		return "PVC " + s.PersistentVolumeClaim.ClaimName
+	case s.EmptyDir != nil:
+		if s.EmptyDir.Medium != "" {
+			return fmt.Sprintf("EmptyDir (%s)", s.EmptyDir.Medium)
 		}
+		return "EmptyDir"
+	case s.HostPath != nil:
+		return "HostPath " + s.HostPath.Path
+	case s.Projected != nil:
+		return fmt.Sprintf("Projected (%d sources)", len(s.Projected.Sources))
+	case s.DownwardAPI != nil:
+		return "DownwardAPI"
+	case s.CSI != nil:
+		return "CSI " + s.CSI.Driver
+	case s.NFS != nil:
+		return fmt.Sprintf("NFS %s:%s", s.NFS.Server, s.NFS.Path)
+	case s.Ephemeral != nil:
+		return "Ephemeral"
+	}
+	return "Other"
+}
 
-		b.WriteString(fmt.Sprintf("  CPU Usage:\t%s (Requests: %s, Limits: %s)\n",
-			formatMilliCPU(cpuUsage), cpuReqPercent, cpuLimPercent))
-		b.WriteString(fmt.Sprintf("  Memory Usage:\t%s (Requests: %s, Limits: %s)\n",
-			formatMiBMemory(memUsage), memReqPercent, memLimPercent))
+// formatEnvValue shows an env var's literal value, or where it comes from the
+// way `kubectl describe` does. Referenced secrets are never read.
+func formatEnvValue(e v1.EnvVar) string {
+	src := e.ValueFrom
+	switch {
+	case src == nil:
+		return e.Value
+	case src.ConfigMapKeyRef != nil:
+		return fmt.Sprintf("<configmap %s/%s>", src.ConfigMapKeyRef.Name, src.ConfigMapKeyRef.Key)
+	case src.SecretKeyRef != nil:
+		return fmt.Sprintf("<secret %s/%s>", src.SecretKeyRef.Name, src.SecretKeyRef.Key)
+	case src.FieldRef != nil:
+		return fmt.Sprintf("<field %s>", src.FieldRef.FieldPath)
+	case src.ResourceFieldRef != nil:
+		return fmt.Sprintf("<resource %s>", src.ResourceFieldRef.Resource)
 	}
+	return "<unknown source>"
+}
 
-	b.WriteString("\n" + m.styles.HeaderText.Render("Containers") + "\n")
-	for _, c := range pod.Spec.Containers {
-		readyStyle := m.styles.Muted
-		if getContainerStatus(pod, c.Name) {
-			readyStyle = m.styles.Success
+func formatEnvFrom(from v1.EnvFromSource) string {
+	var s string
+	switch {
+	case from.ConfigMapRef != nil:
+		s = "all keys of configmap " + from.ConfigMapRef.Name
+	case from.SecretRef != nil:
+		s = "all keys of secret " + from.SecretRef.Name
+	}
+	if from.Prefix != "" {
+		s += fmt.Sprintf(" (prefix %s)", from.Prefix)
+	}
+	return s
+}
+
+func findContainerStatus(statuses []v1.ContainerStatus, name string) *v1.ContainerStatus {
+	for i := range statuses {
+		if statuses[i].Name == name {
+			return &statuses[i]
 		}
-		b.WriteString(fmt.Sprintf("  - Name:\t%s\n", c.Name))
-		b.WriteString(fmt.Sprintf("    Image:\t%s\n", c.Image))
-		b.WriteString(fmt.Sprintf("    Ready:\t%s\n", readyStyle.Render(fmt.Sprintf("%t", getContainerStatus(pod, c.Name)))))
 	}
+	return nil
+}
 
+// formatContainerState describes a container state like `kubectl describe`:
+// why it is waiting, or how and when it last terminated.
+func (m *model) formatContainerState(label string, s v1.ContainerState) string {
+	var b strings.Builder
+	switch {
+	case s.Waiting != nil:
+		b.WriteString(fmt.Sprintf("    %s:\t%s\n", label, m.styles.Warning.Render("Waiting")))
+		b.WriteString(fmt.Sprintf("      Reason:\t%s\n", s.Waiting.Reason))
+		if s.Waiting.Message != "" {
+			b.WriteString(fmt.Sprintf("      Message:\t%s\n", s.Waiting.Message))
+		}
+	case s.Running != nil:
+		b.WriteString(fmt.Sprintf("    %s:\t%s\n", label, m.styles.Success.Render("Running")))
+		b.WriteString(fmt.Sprintf("      Started:\t%s\n", s.Running.StartedAt.Format(time.RFC1123)))
+	case s.Terminated != nil:
+		t := s.Terminated
+		style := m.styles.Error
+		if t.ExitCode == 0 {
+			style = m.styles.Muted
+		}
+		b.WriteString(fmt.Sprintf("    %s:\t%s\n", label, style.Render("Terminated")))
+		b.WriteString(fmt.Sprintf("      Reason:\t%s\n", t.Reason))
+		b.WriteString(fmt.Sprintf("      Exit Code:\t%d\n", t.ExitCode))
+		if t.Signal != 0 {
+			b.WriteString(fmt.Sprintf("      Signal:\t%d\n", t.Signal))
+		}
+		if t.Message != "" {
+			b.WriteString(fmt.Sprintf("      Message:\t%s\n", t.Message))
+		}
+		b.WriteString(fmt.Sprintf("      Finished:\t%s\n", t.FinishedAt.Format(time.RFC1123)))
+	}
 	return b.String()
 }
 
@@ -1541,6 +7537,9 @@ func (m *model) formatPVCDetails(pvc v1.PersistentVolumeClaim) string {
 
 	capacity := pvc.Status.Capacity[v1.ResourceStorage]
 	b.WriteString(fmt.Sprintf("Capacity:\t%s\n", capacity.String()))
+	if stats, ok := m.pvcUsage[pvc.Namespace+"/"+pvc.Name]; ok {
+		b.WriteString(fmt.Sprintf("Used:\t\t%s of %s (%s%%)\n", formatBytes(stats.used), formatBytes(stats.capacity), formatPercentage(stats.used, stats.capacity)))
+	}
 
 	b.WriteString("\n" + m.styles.HeaderText.Render("Access Modes") + "\n")
 	for _, mode := range pvc.Spec.AccessModes {
@@ -1584,6 +7583,12 @@ func (m *model) formatDeploymentDetails(d appsv1.Deployment) string {
 		*d.Spec.Replicas, d.Status.UpdatedReplicas, d.Status.Replicas, d.Status.AvailableReplicas, d.Status.UnavailableReplicas))
 	b.WriteString(fmt.Sprintf("Strategy:\t%s\n", d.Spec.Strategy.Type))
 
+	b.WriteString("\n" + m.styles.HeaderText.Render("Containers") + "\n")
+	for _, c := range d.Spec.Template.Spec.Containers {
+		b.WriteString(fmt.Sprintf("  - Name:\t%s\n", c.Name))
+		b.WriteString(fmt.Sprintf("    Image:\t%s\n", c.Image))
+	}
+
 	return b.String()
 }
 
@@ -1604,10 +7609,222 @@ func (m *model) formatDaemonSetDetails(d appsv1.DaemonSet) string {
 	b.WriteString(fmt.Sprintf("Namespace:\t%s\n", d.Namespace))
 	b.WriteString(fmt.Sprintf("Pods:\t%d desired | %d current | %d ready\n",
 		d.Status.DesiredNumberScheduled, d.Status.CurrentNumberScheduled, d.Status.NumberReady))
+	if d.Status.NumberMisscheduled > 0 {
+		b.WriteString(m.styles.Warning.Render(fmt.Sprintf("Misscheduled:\t%d pod(s) on nodes they shouldn't run on", d.Status.NumberMisscheduled)) + "\n")
+	}
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Update Strategy") + "\n")
+	strategy := d.Spec.UpdateStrategy
+	if strategy.Type == "" {
+		strategy.Type = appsv1.RollingUpdateDaemonSetStrategyType
+	}
+	b.WriteString(fmt.Sprintf("  Type:\t%s", strategy.Type))
+	if ru := strategy.RollingUpdate; strategy.Type == appsv1.RollingUpdateDaemonSetStrategyType && ru != nil {
+		if ru.MaxUnavailable != nil {
+			b.WriteString(fmt.Sprintf(" (max unavailable %s", ru.MaxUnavailable.String()))
+		} else {
+			b.WriteString(" (max unavailable 1")
+		}
+		if ru.MaxSurge != nil {
+			b.WriteString(fmt.Sprintf(", max surge %s", ru.MaxSurge.String()))
+		}
+		b.WriteString(")")
+	}
+	b.WriteString("\n")
+	desired := d.Status.DesiredNumberScheduled
+	rollout := fmt.Sprintf("%d of %d updated | %d available | %d unavailable",
+		d.Status.UpdatedNumberScheduled, desired, d.Status.NumberAvailable, d.Status.NumberUnavailable)
+	switch {
+	case d.Status.ObservedGeneration < d.Generation:
+		rollout = m.styles.Warning.Render(rollout + " (waiting for the controller to see the latest spec)")
+	case d.Status.UpdatedNumberScheduled < desired || d.Status.NumberAvailable < desired:
+		rollout = m.styles.Warning.Render(rollout + " (rolling out)")
+	default:
+		rollout = m.styles.Success.Render(rollout + " (complete)")
+	}
+	b.WriteString("  Rollout:\t" + rollout + "\n")
+
+	b.WriteString("\n" + m.styles.HeaderText.Render("Node Coverage") + "\n")
+	if m.daemonSetCoverage == nil {
+		b.WriteString(m.styles.Muted.Render("  Loading nodes...") + "\n")
+		return b.String()
+	}
+	var eligible, scheduled, ready int
+	for _, r := range m.daemonSetCoverage {
+		if r.eligible {
+			eligible++
+		}
+		if r.pod != "" {
+			scheduled++
+		}
+		if r.ready {
+			ready++
+		}
+	}
+	b.WriteString(fmt.Sprintf("  %d node(s) | %d eligible | %d scheduled | %d ready\n", len(m.daemonSetCoverage), eligible, scheduled, ready))
+	for _, r := range m.daemonSetCoverage {
+		line := fmt.Sprintf("  %-"+"40s %-"+"45s %s", r.node, r.pod, r.status)
+		switch {
+		case !r.eligible && r.pod == "":
+			line = m.styles.Muted.Render(line)
+		case r.pod == "" || !r.ready:
+			line = m.styles.Error.Render(line)
+		}
+		b.WriteString(line + "\n")
+	}
 
 	return b.String()
 }
 
+// daemonSetNodeRow is one node in a DaemonSet's coverage: the pod it runs
+// there, or why there is none.
+type daemonSetNodeRow struct {
+	node     string
+	pod      string // Empty when no pod of the DaemonSet targets the node
+	status   string // Pod status, or why the DaemonSet skips the node
+	ready    bool
+	eligible bool // The DaemonSet's selector, affinity and tolerations admit the node
+}
+
+// daemonSetTolerations are added to every DaemonSet pod by the controller, so
+// nodes with these taints still get one.
+var daemonSetTolerations = []v1.Toleration{
+	{Key: "node.kubernetes.io/not-ready", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: "node.kubernetes.io/unreachable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoExecute},
+	{Key: "node.kubernetes.io/disk-pressure", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: "node.kubernetes.io/memory-pressure", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: "node.kubernetes.io/pid-pressure", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+	{Key: "node.kubernetes.io/unschedulable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+}
+
+// buildDaemonSetCoverage matches the DaemonSet's pods to nodes. Nodes missing
+// a pod they should have come first, then pods that aren't ready, then healthy
+// ones, then nodes the DaemonSet skips.
+func buildDaemonSetCoverage(d appsv1.DaemonSet, nodes []v1.Node, pods []v1.Pod) []daemonSetNodeRow {
+	podOnNode := make(map[string]v1.Pod)
+	for _, pod := range pods {
+		if ref := metav1.GetControllerOf(&pod); ref == nil || ref.Kind != "DaemonSet" || ref.Name != d.Name {
+			continue
+		}
+		node := pod.Spec.NodeName
+		if node == "" {
+			node = daemonPodTargetNode(pod) // Pending pods are pinned to their node by affinity
+		}
+		podOnNode[node] = pod
+	}
+
+	rows := make([]daemonSetNodeRow, 0, len(nodes))
+	for _, node := range nodes {
+		reason := daemonSetSkipReason(d.Spec.Template.Spec, node)
+		row := daemonSetNodeRow{node: node.Name, eligible: reason == "", status: reason}
+		if pod, ok := podOnNode[node.Name]; ok {
+			row.pod = pod.Name
+			row.status = getPodStatus(pod)
+			row.ready = isPodReady(pod)
+		} else if row.eligible {
+			row.status = "no pod scheduled"
+		}
+		rows = append(rows, row)
+	}
+	rank := func(r daemonSetNodeRow) int {
+		switch {
+		case r.eligible && r.pod == "":
+			return 0
+		case r.pod != "" && !r.ready:
+			return 1
+		case r.pod != "":
+			return 2
+		}
+		return 3
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rank(rows[i]) != rank(rows[j]) {
+			return rank(rows[i]) < rank(rows[j])
+		}
+		return rows[i].node < rows[j].node
+	})
+	return rows
+}
+
+// daemonPodTargetNode returns the node a DaemonSet pod is pinned to through
+// the metadata.name field affinity the controller sets.
+func daemonPodTargetNode(pod v1.Pod) string {
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil || pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, f := range term.MatchFields {
+			if f.Key == "metadata.name" && f.Operator == v1.NodeSelectorOpIn && len(f.Values) == 1 {
+				return f.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// daemonSetSkipReason explains why pods of spec don't belong on node, or
+// returns "" when they do: a nodeSelector or required node affinity that
+// doesn't match, or a NoSchedule/NoExecute taint that isn't tolerated.
+func daemonSetSkipReason(spec v1.PodSpec, node v1.Node) string {
+	if len(spec.NodeSelector) > 0 && !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return "nodeSelector doesn't match"
+	}
+	if a := spec.Affinity; a != nil && a.NodeAffinity != nil && a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution != nil {
+		if !matchesNodeSelectorTerms(a.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, node) {
+			return "node affinity doesn't match"
+		}
+	}
+	tolerations := slices.Concat(spec.Tolerations, daemonSetTolerations)
+	if spec.HostNetwork {
+		tolerations = append(tolerations, v1.Toleration{Key: "node.kubernetes.io/network-unavailable", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule})
+	}
+	for _, taint := range node.Spec.Taints {
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		if !slices.ContainsFunc(tolerations, func(t v1.Toleration) bool { return t.ToleratesTaint(&taint) }) {
+			return "taint " + taint.ToString() + " not tolerated"
+		}
+	}
+	return ""
+}
+
+// matchesNodeSelectorTerms reports whether node satisfies any of the terms,
+// each of which requires all its expressions and fields to match.
+func matchesNodeSelectorTerms(terms []v1.NodeSelectorTerm, node v1.Node) bool {
+	fields := labels.Set{"metadata.name": node.Name}
+	for _, term := range terms {
+		if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+			continue // An empty term matches no objects
+		}
+		if matchesRequirements(term.MatchExpressions, labels.Set(node.Labels)) && matchesRequirements(term.MatchFields, fields) {
+			return true
+		}
+	}
+	return false
+}
+
+// nodeSelectorOperators maps node selector operators to their label selector
+// equivalents.
+var nodeSelectorOperators = map[v1.NodeSelectorOperator]selection.Operator{
+	v1.NodeSelectorOpIn:           selection.In,
+	v1.NodeSelectorOpNotIn:        selection.NotIn,
+	v1.NodeSelectorOpExists:       selection.Exists,
+	v1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+	v1.NodeSelectorOpGt:           selection.GreaterThan,
+	v1.NodeSelectorOpLt:           selection.LessThan,
+}
+
+func matchesRequirements(reqs []v1.NodeSelectorRequirement, set labels.Set) bool {
+	for _, r := range reqs {
+		req, err := labels.NewRequirement(r.Key, nodeSelectorOperators[r.Operator], r.Values)
+		if err != nil || !req.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *model) formatServiceDetails(s v1.Service) string {
 	var b strings.Builder
 	b.WriteString(fmt.Sprintf("Name:\t\t%s\n", s.Name))
@@ -1685,12 +7902,13 @@ func (m *model) formatNetworkPolicyDetails(p networkingv1.NetworkPolicy) string
 }
 
 func (m *model) getStatusStyle(status string) lipgloss.Style {
-	switch strings.ToLower(status) {
-	case "running", "bound", "ready", "available", "active":
+	switch strings.TrimPrefix(strings.ToLower(status), "init:") {
+	case "running", "bound", "ready", "available", "active", "succeeded", "completed":
 		return m.styles.Success
-	case "pending":
+	case "pending", "containercreating", "podinitializing", "terminating", "ready,schedulingdisabled":
 		return m.styles.Warning
-	case "failed", "error", "notready", "terminated", "lost":
+	case "failed", "error", "notready", "terminated", "lost", "crashloopbackoff", "imagepullbackoff",
+		"errimagepull", "oomkilled", "createcontainerconfigerror", "invalidimagename", "evicted", "notready,schedulingdisabled":
 		return m.styles.Error
 	default:
 		return m.styles.Muted
@@ -1698,15 +7916,131 @@ func (m *model) getStatusStyle(status string) lipgloss.Style {
 }
 
 func getNodeStatus(node v1.Node) string {
+	status := "Unknown"
 	for _, c := range node.Status.Conditions {
 		if c.Type == v1.NodeReady {
+			status = "NotReady"
 			if c.Status == v1.ConditionTrue {
-				return "Ready"
+				status = "Ready"
+			}
+			break
+		}
+	}
+	if node.Spec.Unschedulable {
+		status += ",SchedulingDisabled"
+	}
+	return status
+}
+
+// getPodStatus summarizes a pod the way kubectl's STATUS column does: the
+// reason a container is stuck (CrashLoopBackOff, ErrImagePull, ...) wins over
+// the pod phase, which stays "Running" while containers crash.
+func getPodStatus(pod v1.Pod) string {
+	if pod.DeletionTimestamp != nil {
+		return "Terminating"
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" && cs.State.Waiting.Reason != "PodInitializing" {
+			return "Init:" + cs.State.Waiting.Reason
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.ExitCode != 0 {
+			return "Init:Error"
+		}
+	}
+	status := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		status = pod.Status.Reason
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.State.Waiting != nil && cs.State.Waiting.Reason != "" {
+			return cs.State.Waiting.Reason
+		}
+		if cs.State.Terminated != nil && cs.State.Terminated.Reason != "" && pod.Status.Phase != v1.PodSucceeded {
+			status = cs.State.Terminated.Reason
+		}
+	}
+	return status
+}
+
+// podQOSClass returns the pod's QoS class, which decides with its priority
+// the order the kubelet evicts pods in under node pressure. It is computed
+// from requests and limits when the status doesn't have it yet: Guaranteed
+// when every container limits CPU and memory and requests exactly that,
+// BestEffort when nothing requests or limits either, Burstable otherwise.
+func podQOSClass(pod v1.Pod) v1.PodQOSClass {
+	if pod.Status.QOSClass != "" {
+		return pod.Status.QOSClass
+	}
+	guaranteed, hasResources := true, false
+	for _, c := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		for _, name := range []v1.ResourceName{v1.ResourceCPU, v1.ResourceMemory} {
+			request, hasRequest := c.Resources.Requests[name]
+			limit, hasLimit := c.Resources.Limits[name]
+			if (hasRequest && !request.IsZero()) || (hasLimit && !limit.IsZero()) {
+				hasResources = true
 			}
-			return "NotReady"
+			if !hasLimit || limit.IsZero() {
+				guaranteed = false
+			} else if hasRequest && request.Cmp(limit) != 0 { // A missing request defaults to the limit
+				guaranteed = false
+			}
+		}
+	}
+	switch {
+	case !hasResources:
+		return v1.PodQOSBestEffort
+	case guaranteed:
+		return v1.PodQOSGuaranteed
+	}
+	return v1.PodQOSBurstable
+}
+
+// qosCell renders the pod's QoS class padded for the pod list, BestEffort
+// highlighted as the first to go when a node evicts.
+func (m *model) qosCell(pod v1.Pod) string {
+	qos := string(podQOSClass(pod))
+	if qos == string(v1.PodQOSBestEffort) {
+		return m.styles.Warning.Render(fmt.Sprintf("%-"+"10s", qos))
+	}
+	return fmt.Sprintf("%-"+"10s", qos)
+}
+
+// formatPodPriority shows the pod's priority with its class, e.g.
+// "1000000 (high)", or "---" when admission hasn't resolved it.
+func formatPodPriority(pod v1.Pod) string {
+	if pod.Spec.Priority == nil {
+		if pod.Spec.PriorityClassName != "" {
+			return "--- (" + pod.Spec.PriorityClassName + ")"
+		}
+		return "---"
+	}
+	if pod.Spec.PriorityClassName == "" {
+		return strconv.Itoa(int(*pod.Spec.Priority))
+	}
+	return fmt.Sprintf("%d (%s)", *pod.Spec.Priority, pod.Spec.PriorityClassName)
+}
+
+// oomKilledContainers returns the statuses of containers whose current or
+// previous instance was killed for running out of memory.
+func oomKilledContainers(pod v1.Pod) []v1.ContainerStatus {
+	var killed []v1.ContainerStatus
+	for _, cs := range append(pod.Status.InitContainerStatuses, pod.Status.ContainerStatuses...) {
+		if oomTermination(cs) != nil {
+			killed = append(killed, cs)
 		}
 	}
-	return "Unknown"
+	return killed
+}
+
+// oomTermination returns the most recent OOM kill of the container, if any.
+func oomTermination(cs v1.ContainerStatus) *v1.ContainerStateTerminated {
+	if t := cs.State.Terminated; t != nil && t.Reason == "OOMKilled" {
+		return t
+	}
+	if t := cs.LastTerminationState.Terminated; t != nil && t.Reason == "OOMKilled" {
+		return t
+	}
+	return nil
 }
 
 func getNodeRoles(node v1.Node) string {
@@ -1719,16 +8053,40 @@ func getNodeRoles(node v1.Node) string {
 	if len(roles) == 0 {
 		return "<none>"
 	}
+	sort.Strings(roles)
 	return strings.Join(roles, ",")
 }
 
-func getContainerStatus(pod v1.Pod, containerName string) bool {
-	for _, s := range pod.Status.ContainerStatuses {
-		if s.Name == containerName {
-			return s.Ready
+// nodePressureConditions returns the pressure conditions that are active on
+// the node, e.g. MemoryPressure or DiskPressure.
+func nodePressureConditions(node v1.Node) []string {
+	var active []string
+	for _, c := range node.Status.Conditions {
+		switch c.Type {
+		case v1.NodeMemoryPressure, v1.NodeDiskPressure, v1.NodePIDPressure, v1.NodeNetworkUnavailable:
+			if c.Status == v1.ConditionTrue {
+				active = append(active, string(c.Type))
+			}
+		}
+	}
+	return active
+}
+
+// groupPodsByNode counts the scheduled, non-terminated pods on each node and sums
+// their CPU and memory requests, matching what `kubectl describe node` reports.
+func groupPodsByNode(pods []v1.Pod) map[string]nodeAllocation {
+	allocations := make(map[string]nodeAllocation)
+	for _, pod := range pods {
+		if pod.Spec.NodeName == "" || pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			continue
 		}
+		alloc := allocations[pod.Spec.NodeName]
+		alloc.pods++
+		alloc.cpuRequests.Add(*totalPodCPURequests(pod))
+		alloc.memoryRequests.Add(*totalPodMemoryRequests(pod))
+		allocations[pod.Spec.NodeName] = alloc
 	}
-	return false
+	return allocations
 }
 
 func totalPodCPU(metrics v1beta1.PodMetrics) *resource.Quantity {
@@ -1747,6 +8105,24 @@ func totalPodMemory(metrics v1beta1.PodMetrics) *resource.Quantity {
 	return total
 }
 
+// formatAge renders the time since t in the compact style kubectl uses (e.g. 45s, 12m, 3h, 5d).
+func formatAge(t metav1.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+	d := time.Since(t.Time)
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 48*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	}
+}
+
 func formatMilliCPU(q *resource.Quantity) string {
 	if q == nil {
 		return "---"
@@ -1761,6 +8137,84 @@ func formatMiBMemory(q *resource.Quantity) string {
 	return fmt.Sprintf("%dMi", q.Value()/(1024*1024))
 }
 
+// formatCores renders CPU in cores, rounded to two decimals, e.g. "0.25" or "64".
+func formatCores(q *resource.Quantity) string {
+	if q == nil {
+		return "---"
+	}
+	return strconv.FormatFloat(math.Round(float64(q.MilliValue())/10)/100, 'f', -1, 64)
+}
+
+// formatGiBMemory renders memory in GiB, to a tenth above 1Gi and a hundredth
+// below, e.g. "0.25Gi" or "128Gi".
+func formatGiBMemory(q *resource.Quantity) string {
+	if q == nil {
+		return "---"
+	}
+	gib := float64(q.Value()) / (1024 * 1024 * 1024)
+	scale := 10.0
+	if gib < 1 {
+		scale = 100
+	}
+	return strconv.FormatFloat(math.Round(gib*scale)/scale, 'f', -1, 64) + "Gi"
+}
+
+// formatCPU renders CPU in the units picked with -units or the U key.
+func (m model) formatCPU(q *resource.Quantity) string {
+	if m.wholeUnits {
+		return formatCores(q)
+	}
+	return formatMilliCPU(q)
+}
+
+// formatMemory renders memory in the units picked with -units or the U key.
+func (m model) formatMemory(q *resource.Quantity) string {
+	if m.wholeUnits {
+		return formatGiBMemory(q)
+	}
+	return formatMiBMemory(q)
+}
+
+// cpuAgainst renders CPU used (or requested) against capacity, e.g. "2000m / 4000m (50%)".
+func (m model) cpuAgainst(used, capacity resource.Quantity) string {
+	return fmt.Sprintf("%s / %s (%s%%)", m.formatCPU(&used), m.formatCPU(&capacity), formatPercentage(used.MilliValue(), capacity.MilliValue()))
+}
+
+func (m model) memoryAgainst(used, capacity resource.Quantity) string {
+	return fmt.Sprintf("%s / %s (%s%%)", m.formatMemory(&used), m.formatMemory(&capacity), formatPercentage(used.Value(), capacity.Value()))
+}
+
+// formatBytes renders a byte count with a binary unit, e.g. "1.5Gi".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	value, exp := float64(n)/unit, 0
+	for value >= unit && exp < 3 {
+		value /= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f%si", value, []string{"K", "M", "G", "T"}[exp])
+}
+
+// nextTopN returns the top-N choice following n, wrapping around to the first.
+func nextTopN(n int) int {
+	for _, choice := range topNChoices {
+		if choice > n {
+			return choice
+		}
+	}
+	return topNChoices[0]
+}
+
+func percentOf(val, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(val) * 100 / float64(total)
+}
+
 func formatPercentage(val, total int64) string {
 	if total == 0 {
 		return "0"
@@ -1818,34 +8272,219 @@ func totalPodMemoryLimits(pod v1.Pod) *resource.Quantity {
 
 func main() {
 	var kubeconfig string
+	var topN int
+	var theme string
+	var contexts string
+	var restartThreshold int
 	flag.StringVar(&kubeconfig, "kubeconfig", "", "path to the kubeconfig file")
+	flag.IntVar(&topN, "top", 5, "number of top pods and nodes shown on the dashboard")
+	flag.StringVar(&theme, "theme", "default", "color theme: "+strings.Join(themeNames(), ", "))
+	flag.StringVar(&contexts, "contexts", "", "comma-separated kubeconfig contexts to open as tabs (default: current context)")
+	flag.IntVar(&restartThreshold, "restart-threshold", 5, "highlight pods restarted more often than this")
+	flag.DurationVar(&apiTimeout, "timeout", apiTimeout, "how long to wait for each API call before giving up")
+	var logFile string
+	flag.StringVar(&logFile, "log-file", "", "write JSON debug logs (API calls, errors, view changes) to this file")
+	var notifyRollouts bool
+	flag.BoolVar(&notifyRollouts, "notify-rollouts", true, "after scaling or restarting a deployment, ring the bell and show a message when its rollout ends")
+	var units string
+	flag.StringVar(&units, "units", "milli", "CPU and memory units: milli (millicores, MiB) or whole (cores, GiB)")
+	var namespace string
+	flag.StringVar(&namespace, "namespace", "", "namespace to start in, overriding the one saved from the last run")
+	flag.StringVar(&namespace, "n", "", "shorthand for -namespace")
+	var excludeNamespaces string
+	flag.StringVar(&excludeNamespaces, "exclude-ns", "", "comma-separated namespaces to hide from all-namespaces lists, e.g. kube-system,kube-public; starts in all namespaces unless -namespace is given")
+	var printResource string
+	flag.StringVar(&printResource, "print", "", "print one list (e.g. pods, nodes, deployments) to stdout and exit instead of starting the UI")
 	flag.Parse()
 
+	if units != "milli" && units != "whole" {
+		fmt.Fprintf(os.Stderr, "Unknown units %q, expected milli or whole\n", units)
+		os.Exit(1)
+	}
+
+	newStyles, ok := themes[theme]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown theme %q, expected one of: %s\n", theme, strings.Join(themeNames(), ", "))
+		os.Exit(1)
+	}
+	// lipgloss downsamples colors to what the terminal supports and drops them
+	// entirely when NO_COLOR is set. Without colors the selected row would be
+	// invisible, so fall back to the attribute-only theme.
+	if lipgloss.ColorProfile() == termenv.Ascii {
+		newStyles = monochromeStyles
+	}
+
 	if kubeconfig == "" {
 		home, err := os.UserHomeDir()
 		if err != nil {
-			fmt.Printf("Error getting user home directory: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Error getting user home directory: %v\n", err)
 			os.Exit(1)
 		}
 		kubeconfig = filepath.Join(home, ".kube", "config")
 	}
 
-	config, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if logFile != "" {
+		closeLog, err := openLogFile(logFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error opening log file: %v\n", err)
+			os.Exit(1)
+		}
+		defer closeLog()
+		logger.Info("starting", slog.String("kubeconfig", kubeconfig), slog.String("contexts", contexts))
+	}
+
+	var contextNames []string
+	if contexts != "" {
+		contextNames = strings.Split(contexts, ",")
+	} else {
+		contextNames = []string{""} // The kubeconfig's current context
+	}
+
+	var clusters []clusterTab
+	for _, name := range contextNames {
+		m, err := newModel(kubeconfig, strings.TrimSpace(name), newStyles(), topN)
+		if err != nil {
+			logger.Error("loading context", slog.String("context", name), slog.Any("error", err))
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		m.restartThreshold = int32(restartThreshold)
+		m.wholeUnits = units == "whole"
+		m.notifyRollouts = notifyRollouts
+		clusters = append(clusters, clusterTab{name: strings.TrimSpace(name), model: m})
+	}
+
+	stateFile, err := statePath()
+	if err == nil {
+		var state savedState
+		state, err = loadState(stateFile)
+		for i := range clusters {
+			clusters[i].model.restoreState(state)
+		}
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Ignoring saved state: %v\n", err)
+	}
+	var excluded []string
+	for _, ns := range strings.Split(excludeNamespaces, ",") {
+		if ns = strings.TrimSpace(ns); ns != "" {
+			excluded = append(excluded, ns)
+		}
+	}
+	for i := range clusters {
+		clusters[i].model.excludedNamespaces = excluded
+		if len(excluded) > 0 {
+			clusters[i].model.selectedNamespace = "" // The exclusions only apply to all namespaces
+		}
+		if namespace != "" {
+			clusters[i].model.selectedNamespace = namespace
+		}
+	}
+
+	if printResource != "" {
+		if err := printOnce(clusters[0].model, printResource, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	var initialModel tea.Model = clusters[0].model
+	if len(clusters) > 1 {
+		initialModel = tabs{tabs: clusters, styles: newStyles()}
+	}
+
+	p := tea.NewProgram(initialModel, tea.WithAltScreen())
+	finalModel, err := p.Run()
+	stopAll() // Also covers exits that don't go through the quit key, like a kill signal
 	if err != nil {
-		fmt.Printf("Error building kubeconfig: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Alas, there's been an error: %v\n", err)
 		os.Exit(1)
 	}
+	if t, ok := finalModel.(tabs); ok {
+		finalModel = t.tabs[t.active].model
+	}
+	if m, ok := finalModel.(model); ok && stateFile != "" {
+		if err := saveState(stateFile, m.currentState()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving state: %v\n", err)
+		}
+	}
+}
+
+// printOnce fetches one list and writes it to w without the interactive
+// chrome, for scripts and cron jobs. resource uses the state file's view
+// names, and the list is scoped to the last used namespace.
+func printOnce(m model, resource string, w io.Writer) error {
+	found := false
+	var names []string
+	for v, name := range stateViews {
+		names = append(names, name)
+		if name == resource {
+			m.view, found = v, true
+		}
+	}
+	if !found {
+		sort.Strings(names)
+		return fmt.Errorf("unknown resource %q, expected one of: %s", resource, strings.Join(names, ", "))
+	}
+
+	_, fetch := m.update(tickMsg{})
+	msgs := []tea.Msg{fetch()}
+	if batch, ok := msgs[0].(tea.BatchMsg); ok { // e.g. PVCs and their usage
+		msgs = msgs[:0]
+		for _, cmd := range batch {
+			msgs = append(msgs, cmd())
+		}
+	}
+	for _, msg := range msgs {
+		switch e := msg.(type) {
+		case errMsg:
+			return e.err
+		case forbiddenMsg:
+			return e
+		}
+		updated, _ := m.Update(msg) // The follow-up refresh tick is dropped
+		m = updated.(model)
+	}
+
+	m.cursor = -1                       // No selected row
+	m.viewport.Height = m.listLen() + 5 // Room for every row, so nothing scrolls
+	_, err := fmt.Fprintln(w, strings.TrimRight(m.renderViewContent(), "\n"))
+	return err
+}
+
+// newModel creates the model for one kubeconfig context, "" for the current one.
+func newModel(kubeconfig, context string, styles Styles, topN int) (model, error) {
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		&clientcmd.ClientConfigLoadingRules{ExplicitPath: kubeconfig},
+		&clientcmd.ConfigOverrides{CurrentContext: context},
+	).ClientConfig()
+	if err != nil {
+		if context != "" {
+			return model{}, fmt.Errorf("could not load context %q from kubeconfig at %s: %w", context, kubeconfig, err)
+		}
+		return model{}, fmt.Errorf("could not load kubeconfig at %s: %w", kubeconfig, err)
+	}
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper { return loggingTransport{rt} })
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		fmt.Printf("Error creating clientset: %v\n", err)
-		os.Exit(1)
+		return model{}, fmt.Errorf("creating clientset: %w", err)
 	}
 
 	metricsClientset, err := metrics.NewForConfig(config)
 	if err != nil {
-		fmt.Printf("Error creating metrics clientset: %v\n", err)
-		os.Exit(1)
+		return model{}, fmt.Errorf("creating metrics clientset: %w", err)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return model{}, fmt.Errorf("creating dynamic client: %w", err)
+	}
+
+	metadataClient, err := metadata.NewForConfig(config)
+	if err != nil {
+		return model{}, fmt.Errorf("creating metadata client: %w", err)
 	}
 
 	ti := textinput.New()
@@ -1853,17 +8492,17 @@ func main() {
 	ti.CharLimit = 3
 	ti.Width = 5
 
-	initialModel := model{
+	return model{
+		kubeconfig:       kubeconfig,
+		kubeContext:      context,
 		clientset:        clientset,
 		metricsClientset: metricsClientset,
-		styles:           defaultStyles(),
+		dynamicClient:    dynamicClient,
+		metadataClient:   metadataClient,
+		styles:           styles,
 		textInput:        ti,
-		resourceTypes:    []string{"Nodes", "Pods", "Deployments", "StatefulSets", "DaemonSets", "Services", "PVCs", "PVs", "Network Policies", "Events"},
-	}
-
-	p := tea.NewProgram(initialModel, tea.WithAltScreen())
-	if err := p.Start(); err != nil {
-		fmt.Printf("Alas, there's been an error: %v", err)
-		os.Exit(1)
-	}
+		topN:             topN,
+		progress:         progress.New(progress.WithDefaultGradient(), progress.WithWidth(40)),
+		resourceTypes:    []string{"Nodes", "Pods", "Deployments", "StatefulSets", "DaemonSets", "Services", "PVCs", "PVs", "Network Policies", "Events", "Roles", "RoleBindings", "ResourceQuotas", "LimitRanges", "PDBs", "CronJobs", "EndpointSlices", "Custom Resources", "Node Map", "Resource Counts"},
+	}, nil
 }
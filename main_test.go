@@ -0,0 +1,2398 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NimbleMarkets/ntcharts/linechart/timeserieslinechart"
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	v1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/managedfields"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	discoveryfake "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	metadatafake "k8s.io/client-go/metadata/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+	metricsfake "k8s.io/metrics/pkg/client/clientset/versioned/fake"
+)
+
+type confirmedMsg struct{}
+
+func keyPress(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+func TestConfirmRunsActionOnlyOnYes(t *testing.T) {
+	ran := 0
+	action := func() tea.Msg {
+		ran++
+		return confirmedMsg{}
+	}
+
+	m := model{view: viewDetails}
+	m.askConfirm("Proceed?", action)
+
+	updated, cmd := m.Update(keyPress("n"))
+	if cmd != nil {
+		cmd()
+	}
+	if ran != 0 {
+		t.Fatalf("action ran on \"n\"")
+	}
+	if v := updated.(model).view; v != viewDetails {
+		t.Fatalf("view after \"n\" = %v, want viewDetails", v)
+	}
+
+	m.askConfirm("Proceed?", action)
+	updated, cmd = m.Update(keyPress("y"))
+	if cmd == nil {
+		t.Fatalf("no command returned on \"y\"")
+	}
+	if _, ok := cmd().(confirmedMsg); !ok || ran != 1 {
+		t.Fatalf("action did not run on \"y\"")
+	}
+	if updated.(model).confirmAction != nil {
+		t.Fatalf("confirm action not cleared after \"y\"")
+	}
+}
+
+func TestNumberLines(t *testing.T) {
+	in := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	got := numberLines(in)
+	want := " 1  a\n 2  b\n 3  c\n 4  d\n 5  e\n 6  f\n 7  g\n 8  h\n 9  i\n10  j\n"
+	if got != want {
+		t.Fatalf("numberLines() = %q, want %q", got, want)
+	}
+}
+
+func TestRolloutStatus(t *testing.T) {
+	replicas := int32(3)
+	d := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	d.Name = "web"
+
+	d.Status = appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 2, AvailableReplicas: 2}
+	if _, done := rolloutStatus(d); done {
+		t.Fatalf("rollout reported done with 2 of 3 replicas updated")
+	}
+
+	d.Status = appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}
+	if msg, done := rolloutStatus(d); !done {
+		t.Fatalf("rollout not done after convergence: %s", msg)
+	}
+
+	d.Generation = 2
+	if _, done := rolloutStatus(d); done {
+		t.Fatalf("rollout reported done before the new generation was observed")
+	}
+}
+
+func TestStatefulSetAndDaemonSetRolloutStatus(t *testing.T) {
+	replicas, partition := int32(5), int32(3)
+	s := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: &replicas, UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition},
+	}}}
+	s.Name, s.Namespace, s.Generation = "db", "data", 4
+	s.Status = appsv1.StatefulSetStatus{ObservedGeneration: 4, ReadyReplicas: 5, UpdatedReplicas: 1, CurrentReplicas: 4, CurrentRevision: "db-1", UpdateRevision: "db-2"}
+	if msg, done := statefulSetRolloutStatus(s); done || !strings.Contains(msg, "1 out of 2 new pods") {
+		t.Errorf("partitioned rollout with 1 of 2 pods updated: %q, done %t", msg, done)
+	}
+	s.Status.UpdatedReplicas, s.Status.CurrentReplicas = 2, 3
+	if msg, done := statefulSetRolloutStatus(s); !done {
+		t.Errorf("partitioned rollout not done once the pods above the partition are updated: %q", msg)
+	}
+	s.Spec.UpdateStrategy.RollingUpdate = nil
+	if msg, done := statefulSetRolloutStatus(s); done {
+		t.Errorf("unpartitioned rollout done while revisions differ: %q", msg)
+	}
+
+	d := &appsv1.DaemonSet{}
+	d.Name, d.Generation = "agent", 2
+	d.Status = appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 3, UpdatedNumberScheduled: 3, NumberAvailable: 2}
+	if msg, done := daemonSetRolloutStatus(d); done || !strings.Contains(msg, "2 of 3 updated pods are available") {
+		t.Errorf("daemon set with an unavailable pod: %q, done %t", msg, done)
+	}
+	d.Status.NumberAvailable = 3
+	if msg, done := daemonSetRolloutStatus(d); !done {
+		t.Errorf("daemon set not done after convergence: %q", msg)
+	}
+
+	s.Spec.UpdateStrategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: &partition}
+	m := model{view: viewDetails, viewStack: []viewState{viewStatefulSets}, statefulsets: []appsv1.StatefulSet{*s}, styles: defaultStyles(), progress: progress.New()}
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m = updated.(model)
+	if m.view != viewRolloutStatus || m.rolloutWorkload.name != "db" || cmd == nil {
+		t.Fatalf("s opened view %v for %+v, want the db rollout", m.view, m.rolloutWorkload)
+	}
+	updated, _ = m.Update(getRolloutStatus(kubefake.NewSimpleClientset(s), m.rolloutWorkload)())
+	m = updated.(model)
+	if got := m.renderRolloutStatus(); !strings.Contains(got, "Partition: 3") || !strings.Contains(got, "partitioned roll out complete") {
+		t.Errorf("rollout view:\n%s", got)
+	}
+}
+
+func TestPreviousReplicaSet(t *testing.T) {
+	d := &appsv1.Deployment{}
+	d.Name = "web"
+	d.UID = "deploy-uid"
+	d.Annotations = map[string]string{"deployment.kubernetes.io/revision": "3"}
+
+	isController := true
+	rs := func(name, revision string, owner *appsv1.Deployment) appsv1.ReplicaSet {
+		r := appsv1.ReplicaSet{}
+		r.Name = name
+		r.Annotations = map[string]string{"deployment.kubernetes.io/revision": revision}
+		if owner != nil {
+			r.OwnerReferences = []metav1.OwnerReference{{UID: owner.UID, Controller: &isController}}
+		}
+		return r
+	}
+	other := &appsv1.Deployment{}
+	other.UID = "other-uid"
+
+	replicaSets := []appsv1.ReplicaSet{
+		rs("web-1", "1", d),
+		rs("web-3", "3", d),
+		rs("web-2", "2", d),
+		rs("api-2", "2", other),
+		rs("orphan", "2", nil),
+	}
+	got, err := previousReplicaSet(d, replicaSets)
+	if err != nil {
+		t.Fatalf("previousReplicaSet() error = %v", err)
+	}
+	if got.Name != "web-2" {
+		t.Fatalf("previousReplicaSet() = %s, want web-2", got.Name)
+	}
+
+	if _, err := previousReplicaSet(d, replicaSets[1:2]); err == nil {
+		t.Fatalf("expected an error when no previous revision exists")
+	}
+}
+
+func TestGroupPodsByNode(t *testing.T) {
+	pod := func(node string, phase v1.PodPhase, cpu, mem string) v1.Pod {
+		p := v1.Pod{}
+		p.Spec.NodeName = node
+		p.Status.Phase = phase
+		p.Spec.Containers = []v1.Container{{
+			Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+				v1.ResourceCPU:    resource.MustParse(cpu),
+				v1.ResourceMemory: resource.MustParse(mem),
+			}},
+		}}
+		return p
+	}
+
+	allocations := groupPodsByNode([]v1.Pod{
+		pod("node-a", v1.PodRunning, "100m", "64Mi"),
+		pod("node-a", v1.PodPending, "250m", "128Mi"),
+		pod("node-a", v1.PodSucceeded, "1", "1Gi"),
+		pod("node-b", v1.PodRunning, "500m", "256Mi"),
+		pod("", v1.PodPending, "1", "1Gi"),
+	})
+
+	a := allocations["node-a"]
+	if a.pods != 2 || a.cpuRequests.MilliValue() != 350 || a.memoryRequests.Value() != 192*1024*1024 {
+		t.Fatalf("node-a allocation = %d pods, %dm CPU, %d bytes", a.pods, a.cpuRequests.MilliValue(), a.memoryRequests.Value())
+	}
+	if b := allocations["node-b"]; b.pods != 1 {
+		t.Fatalf("node-b pods = %d, want 1", b.pods)
+	}
+	if len(allocations) != 2 {
+		t.Fatalf("got allocations for %d nodes, want 2", len(allocations))
+	}
+}
+
+func TestNodesListWithoutPodAccess(t *testing.T) {
+	node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	m := model{view: viewNodes, styles: defaultStyles()}
+	m.viewport.Height = 20
+	updated, _ := m.Update(nodesMsg{nodes: []v1.Node{node}}) // No allocations: pods couldn't be listed
+	m = updated.(model)
+
+	var row string
+	for _, line := range strings.Split(ansi.Strip(m.renderNodesList()), "\n") {
+		if strings.Contains(line, "node-a") {
+			row = line
+		}
+	}
+	if got := strings.Count(row, "---"); got != 5 {
+		t.Fatalf("row = %q, want --- for CPU%%, MEM%%, PODS, CPU REQ%% and MEM REQ%%", row)
+	}
+}
+
+func TestParseCanIQuery(t *testing.T) {
+	q, err := parseCanIQuery("create deployments.apps/scale -n prod --as prod:deployer", "default")
+	if err != nil {
+		t.Fatalf("parseCanIQuery() error = %v", err)
+	}
+	want := canIQuery{verb: "create", resource: "deployments", subresource: "scale", group: "apps", namespace: "prod", serviceAccount: "prod:deployer"}
+	if q != want {
+		t.Fatalf("parseCanIQuery() = %+v, want %+v", q, want)
+	}
+
+	q, err = parseCanIQuery("list pods", "default")
+	if err != nil || q.namespace != "default" || q.serviceAccount != "" {
+		t.Fatalf("parseCanIQuery(\"list pods\") = %+v, %v", q, err)
+	}
+
+	for _, bad := range []string{"list", "get pods extra", "get pods --as nocolon", "get pods -n"} {
+		if _, err := parseCanIQuery(bad, ""); err == nil {
+			t.Errorf("parseCanIQuery(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestApplyEventWatch(t *testing.T) {
+	event := func(uid, reason string) *v1.Event {
+		e := &v1.Event{Reason: reason}
+		e.UID = types.UID(uid)
+		return e
+	}
+
+	events := []v1.Event{*event("a", "Scheduled"), *event("b", "Pulled")}
+	events = applyEventWatch(events, watch.Event{Type: watch.Added, Object: event("c", "Started")})
+	events = applyEventWatch(events, watch.Event{Type: watch.Modified, Object: event("b", "BackOff")})
+	events = applyEventWatch(events, watch.Event{Type: watch.Deleted, Object: event("a", "")})
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.Reason)
+	}
+	if strings.Join(got, ",") != "BackOff,Started" {
+		t.Fatalf("events after watch = %v, want [BackOff Started]", got)
+	}
+}
+
+func TestEventFollowStaysOnNewest(t *testing.T) {
+	watcher := watch.NewFake()
+	m := model{view: viewEvents, eventWatch: watcher}
+	m.viewport.Height = 15
+	m.events = []v1.Event{{Type: v1.EventTypeNormal, Reason: "Pulled"}, {Type: v1.EventTypeNormal, Reason: "Scheduled"}}
+	m.cursor = 1
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m = updated.(model)
+	if !m.eventFollow || m.cursor != 0 {
+		t.Fatalf("after f: eventFollow = %v, cursor = %d, want true, 0", m.eventFollow, m.cursor)
+	}
+
+	warning := &v1.Event{Type: v1.EventTypeWarning, Reason: "BackOff"}
+	warning.UID = "new"
+	updated, _ = m.Update(eventWatchMsg{watcher: watcher, event: watch.Event{Type: watch.Added, Object: warning}})
+	m = updated.(model)
+	if m.cursor != 0 || m.events[0].Reason != "BackOff" {
+		t.Fatalf("cursor = %d on %q, want 0 on the new BackOff event", m.cursor, m.events[m.cursor].Reason)
+	}
+	if !strings.Contains(m.headerView(), "following") {
+		t.Errorf("header %q doesn't mention following", m.headerView())
+	}
+}
+
+func TestEventsGroupedByObject(t *testing.T) {
+	pod := v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "web-1"}
+	m := model{view: viewEvents}
+	m.viewport.Height = 15
+	m.events = []v1.Event{
+		{InvolvedObject: pod, Type: v1.EventTypeNormal, Reason: "Started", Message: "Started container web", Count: 1},
+		{InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "default", Name: "db-0"}, Type: v1.EventTypeWarning, Reason: "BackOff", Message: "Back-off restarting"},
+		{InvolvedObject: pod, Type: v1.EventTypeNormal, Reason: "Pulled", Message: "Pulled image", Count: 2},
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	m = updated.(model)
+	if got := m.listLen(); got != 2 {
+		t.Fatalf("grouped rows = %d, want 2", got)
+	}
+	groups := groupEvents(m.visibleEvents())
+	if groups[0].object.Name != "web-1" || groups[0].count() != 3 || groups[0].hasWarning() {
+		t.Errorf("first group = %s with count %d, want web-1 with count 3 and no warning", groups[0].object.Name, groups[0].count())
+	}
+	if !groups[1].hasWarning() {
+		t.Errorf("db-0 group isn't flagged as having a warning")
+	}
+	if list := m.renderEventsList(); !strings.Contains(list, "Started container web") || strings.Contains(list, "Pulled image") {
+		t.Errorf("grouped list should show only the latest message per object:\n%s", list)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	if m.view != viewDetails || !strings.Contains(m.details, "Pulled image") || !strings.Contains(m.details, "Started container web") {
+		t.Errorf("details of the group should list all its events, got view %d:\n%s", m.view, m.details)
+	}
+}
+
+func TestQuitStopsWatchesAndCancelsContext(t *testing.T) {
+	saved, savedStop := rootCtx, stopAll
+	rootCtx, stopAll = context.WithCancel(context.Background())
+	defer func() { rootCtx, stopAll = saved, savedStop }()
+
+	watcher := watch.NewFake()
+	m := model{view: viewEvents, eventWatch: watcher}
+	ctx, cancel := apiContext()
+	defer cancel()
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = updated.(model)
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Fatalf("q returned %T, want tea.QuitMsg", cmd())
+	}
+	if !watcher.IsStopped() || m.eventWatch != nil {
+		t.Errorf("event watch still open after quitting")
+	}
+	if ctx.Err() == nil {
+		t.Errorf("in-flight API call context not cancelled after quitting")
+	}
+}
+
+func TestForbiddenListShownInView(t *testing.T) {
+	err := apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", nil)
+	msg := listError(viewPods, "team-a", err)
+	if _, ok := msg.(forbiddenMsg); !ok {
+		t.Fatalf("listError(forbidden) = %T, want forbiddenMsg", msg)
+	}
+	if _, ok := listError(viewPods, "team-a", apierrors.NewNotFound(schema.GroupResource{}, "x")).(errMsg); !ok {
+		t.Fatalf("listError(not found) isn't an errMsg")
+	}
+
+	m := model{view: viewPods}
+	updated, _ := m.Update(msg)
+	m = updated.(model)
+	if m.err != nil {
+		t.Fatalf("forbidden list raised the error banner: %v", m.err)
+	}
+	if got := m.renderViewContent(); !strings.Contains(got, "permission to list secrets in namespace team-a") {
+		t.Fatalf("view = %q, want a permission message", got)
+	}
+
+	updated, _ = m.Update(podsMsg{})
+	m = updated.(model)
+	if got := m.renderViewContent(); strings.Contains(got, "permission") {
+		t.Fatalf("view still shows the permission message after a successful list: %q", got)
+	}
+}
+
+func TestTimeoutErrorExplainsRetry(t *testing.T) {
+	err := fmt.Errorf("Get \"https://apiserver/api/v1/pods\": %w", context.DeadlineExceeded)
+	m := model{view: viewPods}
+	updated, cmd := m.Update(listError(viewPods, "", err))
+	m = updated.(model)
+	if cmd == nil {
+		t.Fatal("timeout stopped the refresh")
+	}
+	if got := m.err.Error(); !strings.Contains(got, "api/v1/pods") || !strings.Contains(got, "API timeout 10s, retrying") {
+		t.Fatalf("banner = %q, want the list error with the timeout and retry explained", got)
+	}
+
+	err = fmt.Errorf("deleting finished pods: batch/report-1: %w", context.DeadlineExceeded)
+	updated, _ = model{view: viewPods}.Update(errMsg{err})
+	m = updated.(model)
+	if got := m.err.Error(); !strings.Contains(got, "batch/report-1") || !strings.Contains(got, "API timeout 10s;") || strings.Contains(got, "retrying") {
+		t.Fatalf("banner = %q, want the action error with the timeout explained, and no retry promised", got)
+	}
+}
+
+func TestActionErrorsKeepOneRefreshLoop(t *testing.T) {
+	m := model{view: viewPods}
+	var seqs []int
+	for _, err := range []error{errors.New("scaling failed"), errors.New("no finished pods")} {
+		updated, _ := m.Update(errMsg{err})
+		m = updated.(model)
+		seqs = append(seqs, m.tickSeq)
+	}
+
+	if _, cmd := m.Update(tickMsg{seq: seqs[0]}); cmd != nil {
+		t.Fatal("the timer armed by the first error still refreshes")
+	}
+	if _, cmd := m.Update(tickMsg{seq: seqs[1]}); cmd == nil {
+		t.Fatal("the timer armed by the last error doesn't refresh")
+	}
+}
+
+func TestDeploymentHealth(t *testing.T) {
+	three := int32(3)
+	deployment := func(status appsv1.DeploymentStatus) *appsv1.Deployment {
+		return &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: &three}, Status: status}
+	}
+	tests := []struct {
+		name string
+		d    *appsv1.Deployment
+		want string
+	}{
+		{"all available", deployment(appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, ReadyReplicas: 3, AvailableReplicas: 3}), "Healthy"},
+		{"rolling out", deployment(appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 2, AvailableReplicas: 3}), "Progressing"},
+		{"replica down", deployment(appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2}), "Degraded"},
+		{"deadline exceeded", deployment(appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 2, AvailableReplicas: 3, Conditions: []appsv1.DeploymentCondition{
+			{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded"},
+		}}), "Degraded"},
+	}
+	for _, tt := range tests {
+		if got := deploymentHealth(tt.d); got != tt.want {
+			t.Errorf("%s: deploymentHealth() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseVolumeStats(t *testing.T) {
+	summary := `{"pods": [{"volume": [
+		{"name": "data", "usedBytes": 858993459, "capacityBytes": 1073741824, "pvcRef": {"name": "data-db-0", "namespace": "prod"}},
+		{"name": "kube-api-access", "usedBytes": 12288, "capacityBytes": 4096000}
+	]}]}`
+	stats, err := parseVolumeStats([]byte(summary))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stats) != 1 {
+		t.Fatalf("got stats for %d volumes, want only the PVC", len(stats))
+	}
+	got := stats["prod/data-db-0"]
+	if got.used != 858993459 || got.capacity != 1073741824 {
+		t.Fatalf("prod/data-db-0 = %+v", got)
+	}
+
+	m := model{pvcUsage: stats, styles: defaultStyles()}
+	pvc := v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "data-db-0"}}
+	if cell := m.pvcUsed(pvc); !strings.Contains(cell, "819.2Mi (80%)") {
+		t.Errorf("pvcUsed() = %q, want 819.2Mi (80%%)", cell)
+	}
+}
+
+func TestPVCUsageTrailsListRefresh(t *testing.T) {
+	m := model{view: viewPVCs, selectedNamespace: "prod"}
+	if m.refreshPVCUsage() == nil {
+		t.Fatal("first refresh didn't read usage")
+	}
+	if m.refreshPVCUsage() != nil {
+		t.Fatal("usage reread on every list refresh")
+	}
+	m.selectedNamespace = "staging"
+	if m.refreshPVCUsage() == nil {
+		t.Fatal("usage not reread after switching namespace")
+	}
+	m.pvcUsageStamp.at = time.Now().Add(-pvcUsageInterval)
+	if m.refreshPVCUsage() == nil {
+		t.Fatal("usage not reread after pvcUsageInterval")
+	}
+}
+
+func TestSearchJumpsToResource(t *testing.T) {
+	meta := func(name string) metav1.ObjectMeta { return metav1.ObjectMeta{Namespace: "shop", Name: name} }
+	clientset := kubefake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: meta("checkout-7d9f-abcde")},
+		&appsv1.Deployment{ObjectMeta: meta("checkout")},
+		&v1.Service{ObjectMeta: meta("cart")},
+		&v1.Service{ObjectMeta: meta("checkout")},
+		&v1.ConfigMap{ObjectMeta: meta("checkout-flags")},
+	)
+	object := func(apiVersion, kind, name string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{TypeMeta: metav1.TypeMeta{APIVersion: apiVersion, Kind: kind}, ObjectMeta: meta(name)}
+	}
+	scheme := metadatafake.NewTestScheme()
+	metav1.AddMetaToScheme(scheme)
+	metadataClient := metadatafake.NewSimpleMetadataClient(scheme,
+		object("v1", "Pod", "checkout-7d9f-abcde"),
+		object("apps/v1", "Deployment", "checkout"),
+		object("v1", "Service", "cart"),
+		object("v1", "Service", "checkout"),
+		object("v1", "ConfigMap", "checkout-flags"),
+	)
+	metadataClient.PrependReactor("list", "cronjobs", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Group: "batch", Resource: "cronjobs"}, "", nil)
+	})
+	msg := getSearchIndex(metadataClient, "shop")()
+	index, ok := msg.(searchIndexMsg)
+	if !ok || index.err != nil {
+		t.Fatalf("getSearchIndex() with CronJobs forbidden = %#v, want the other kinds", msg)
+	}
+
+	m := model{view: viewPods, selectedNamespace: "shop", textInput: textinput.New()}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlP})
+	m = updated.(model)
+	updated, _ = m.Update(index)
+	m = updated.(model)
+	for _, k := range "chkout" {
+		updated, _ = m.Update(keyPress(string(k)))
+		m = updated.(model)
+	}
+	var got []string
+	for _, ref := range m.searchResults() {
+		got = append(got, ref.kind+"/"+ref.name)
+	}
+	if strings.Join(got, ",") != "Deployment/checkout,Service/checkout,ConfigMap/checkout-flags,Pod/checkout-7d9f-abcde" {
+		t.Fatalf("results = %v", got)
+	}
+	if _, err := getResourceObject(context.Background(), clientset, "shop", "checkout-flags", "ConfigMap"); err != nil {
+		t.Fatalf("ConfigMap YAML can't be fetched: %v", err)
+	}
+	cm := m
+	for range 2 {
+		updated, _ = cm.Update(tea.KeyMsg{Type: tea.KeyDown})
+		cm = updated.(model)
+	}
+	if updated, cmd := cm.Update(tea.KeyMsg{Type: tea.KeyEnter}); cmd == nil || updated.(model).view != viewPods {
+		t.Errorf("enter on a ConfigMap should fetch its YAML, got view %v", updated.(model).view)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	if m.view != viewServices {
+		t.Fatalf("view = %v, want the Services view", m.view)
+	}
+	updated, _ = m.Update(servicesMsg{services: []v1.Service{{ObjectMeta: meta("cart")}, {ObjectMeta: meta("checkout")}}, namespace: "shop"})
+	m = updated.(model)
+	if m.cursor != 1 {
+		t.Fatalf("cursor = %d, want the checkout service selected", m.cursor)
+	}
+}
+
+func TestFooterShowsLastUpdate(t *testing.T) {
+	m := model{view: viewPods}
+	if got := m.footerView(); strings.Contains(got, "updated") {
+		t.Fatalf("footer %q shows an update time before any data arrived", got)
+	}
+	updated, _ := m.Update(podsMsg{})
+	m = updated.(model)
+	if got := m.footerView(); !strings.Contains(got, "updated 0s ago") || strings.Contains(got, "stale") {
+		t.Fatalf("footer = %q, want updated 0s ago", got)
+	}
+
+	m.fetched[viewPods] = fetchStamp{at: time.Now().Add(-time.Minute)}
+	if got := m.footerView(); !strings.Contains(got, "updated 1m ago (stale)") {
+		t.Fatalf("footer = %q, want a stale marker", got)
+	}
+}
+
+func TestDetailsFieldCursorCopiesValue(t *testing.T) {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-5f7c"}, Spec: v1.PodSpec{NodeName: "worker-1"},
+		Status: v1.PodStatus{Phase: v1.PodRunning, PodIP: "10.0.0.7"}}
+	m := model{view: viewPods, styles: defaultStyles(), pods: []v1.Pod{pod}}
+	m.viewport.Height = 5
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	fields := detailFields(m.details)
+	if len(fields) < 5 || fields[0].value != "cart-5f7c" {
+		t.Fatalf("detailFields() = %+v", fields)
+	}
+	for range 3 { // Name, Namespace, Status, then Pod IP
+		updated, _ = m.Update(keyPress("]"))
+		m = updated.(model)
+	}
+	if got := fields[m.detailsField].value; got != "10.0.0.7" {
+		t.Errorf("highlighted field = %q, want the pod IP", got)
+	}
+	if !strings.Contains(m.viewport.View(), m.styles.SelectedRow.Render("Pod IP:\t10.0.0.7")) {
+		t.Errorf("the pod IP field isn't highlighted:\n%s", m.viewport.View())
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyCtrlY}); cmd == nil {
+		t.Error("ctrl+y in details should copy the highlighted field")
+	}
+	for range 20 {
+		updated, _ = m.Update(keyPress("]"))
+		m = updated.(model)
+	}
+	if m.detailsField != len(fields)-1 || m.viewport.YOffset == 0 {
+		t.Errorf("detailsField = %d, offset %d; want the last field scrolled into view", m.detailsField, m.viewport.YOffset)
+	}
+}
+
+func TestCopiedNameShownUntilNextKey(t *testing.T) {
+	m := model{view: viewPods, styles: defaultStyles()}
+	m.pods = []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "cart-5f7c"}}}
+	if name, ok := m.selectedName(viewPods); !ok || name != "cart-5f7c" {
+		t.Fatalf("selectedName() = %q, %v", name, ok)
+	}
+
+	updated, _ := m.Update(copiedMsg{text: "cart-5f7c"})
+	m = updated.(model)
+	if got := m.footerView(); !strings.Contains(got, "Copied cart-5f7c") {
+		t.Fatalf("footer = %q, want the copy confirmation", got)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(model)
+	if got := m.footerView(); strings.Contains(got, "Copied") {
+		t.Fatalf("footer = %q, confirmation should clear on the next key", got)
+	}
+
+	// Without a system clipboard the terminal is asked to copy through the next frame
+	updated, _ = m.Update(copiedMsg{text: "cart-5f7c", viaTerminal: true})
+	m = updated.(model)
+	if got := m.footerView(); strings.Contains(got, "Copied") || !strings.Contains(got, "OSC 52") {
+		t.Errorf("footer = %q, shouldn't claim an OSC 52 copy succeeded", got)
+	}
+	m.ready = true
+	if want := ansi.SetSystemClipboard("cart-5f7c"); !strings.HasPrefix(m.View(), want) {
+		t.Errorf("the frame should carry the OSC 52 sequence")
+	}
+	updated, _ = m.Update(clearTerminalSeqMsg{})
+	if m = updated.(model); strings.Contains(m.View(), "\x1b]52") {
+		t.Errorf("the OSC 52 sequence should be written only once")
+	}
+
+	m = model{view: viewNamespaces, namespaces: []v1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "shop"}}}}
+	if _, ok := m.selectedName(viewNamespaces); ok {
+		t.Error("the All Namespaces row has no name to copy")
+	}
+	m.cursor = 1
+	if name, _ := m.selectedName(viewNamespaces); name != "shop" {
+		t.Errorf("selectedName(viewNamespaces) = %q, want shop", name)
+	}
+}
+
+func TestBuildNodeMapGroupsPodsByNode(t *testing.T) {
+	pod := func(ns, name, node string, phase v1.PodPhase) v1.Pod {
+		return v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: name}, Spec: v1.PodSpec{NodeName: node}, Status: v1.PodStatus{Phase: phase}}
+	}
+	nodes := []v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "worker-b"}}, {ObjectMeta: metav1.ObjectMeta{Name: "worker-a"}}}
+	pods := []v1.Pod{
+		pod("shop", "web", "worker-b", v1.PodRunning),
+		pod("kube-system", "dns", "worker-b", v1.PodRunning),
+		pod("shop", "migrate", "worker-a", v1.PodSucceeded),
+		pod("shop", "queued", "", v1.PodPending),
+	}
+	usage := map[string]metricsv1beta1.PodMetrics{
+		"shop/web": {Containers: []metricsv1beta1.ContainerMetrics{{Usage: v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse("250m"), v1.ResourceMemory: resource.MustParse("128Mi"),
+		}}}},
+	}
+
+	var got []string
+	for _, row := range buildNodeMap(nodes, pods, usage) {
+		if row.pod == nil {
+			got = append(got, fmt.Sprintf("%s(%d)", row.node, row.pods))
+		} else {
+			got = append(got, "  "+row.pod.Namespace+"/"+row.pod.Name)
+		}
+	}
+	want := "worker-a(0),worker-b(2),  kube-system/dns,  shop/web,(1),  shop/queued"
+	if strings.Join(got, ",") != want {
+		t.Fatalf("node map = %v, want %s", got, want)
+	}
+
+	rows := buildNodeMap(nodes, pods, usage)
+	if cpu := rows[1].cpu; cpu == nil || cpu.MilliValue() != 250 {
+		t.Errorf("worker-b cpu = %v, want the sum of its pods' usage (250m)", cpu)
+	}
+}
+
+func TestUsageChartFitsTerminalWidth(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	m := model{styles: defaultStyles()}
+	for i := 0; i < 10; i++ {
+		at := start.Add(time.Duration(i) * refreshInterval)
+		m.cpuHistory = append(m.cpuHistory, timeserieslinechart.TimePoint{Time: at, Value: float64(10 * i)})
+		m.memoryHistory = append(m.memoryHistory, timeserieslinechart.TimePoint{Time: at, Value: 50})
+	}
+
+	for _, width := range []int{160, 99, 60, 30, 15} {
+		m.viewport.Width, m.viewport.Height = width, 24
+		chart := m.renderUsageChart()
+		_, _, sideBySide, ok := dashboardChartLayout(width, 24)
+		if sideBySide != (width >= sideBySideChartWidth) || ok != (width >= minChartWidth+4) {
+			t.Errorf("width %d: sideBySide = %v, ok = %v", width, sideBySide, ok)
+		}
+		if !ok {
+			if !strings.Contains(chart, "Widen the terminal") {
+				t.Errorf("width %d: too narrow for a chart, but no hint shown", width)
+			}
+			continue
+		}
+		for _, line := range strings.Split(chart, "\n") {
+			if w := lipgloss.Width(line); w > width {
+				t.Fatalf("width %d: chart line is %d wide:\n%s", width, w, chart)
+			}
+		}
+	}
+}
+
+func TestResourceCountsPerNamespace(t *testing.T) {
+	object := func(apiVersion, kind, namespace, name string) *metav1.PartialObjectMetadata {
+		return &metav1.PartialObjectMetadata{
+			TypeMeta:   metav1.TypeMeta{APIVersion: apiVersion, Kind: kind},
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		}
+	}
+	scheme := metadatafake.NewTestScheme()
+	metav1.AddMetaToScheme(scheme)
+	client := metadatafake.NewSimpleMetadataClient(scheme,
+		object("v1", "Pod", "shop", "web-1"),
+		object("v1", "Pod", "shop", "web-2"),
+		object("v1", "Pod", "kube-system", "dns"),
+		object("apps/v1", "Deployment", "shop", "web"),
+	)
+	client.PrependReactor("list", "secrets", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "", nil)
+	})
+
+	msg, ok := getResourceCounts(client)().(resourceCountsMsg)
+	if !ok {
+		t.Fatalf("getResourceCounts returned %T, want resourceCountsMsg", msg)
+	}
+	m := model{view: viewResourceCounts, resourceCounts: msg.rows}
+	m.viewport.Height = 15
+	m.cursor = -1
+	got := strings.Fields(m.renderResourceCounts())
+	want := strings.Fields(`NAMESPACE PODS DEPLOY STS DS JOBS CRONJOBS SVC CM SECRETS PVC
+		TOTAL 3 1 0 0 0 0 0 0 - 0
+		kube-system 1 0 0 0 0 0 0 0 - 0
+		shop 2 1 0 0 0 0 0 0 - 0`)
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("resource counts =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestWorkloadRestartAndDelete(t *testing.T) {
+	objMeta := metav1.ObjectMeta{Namespace: "infra", Name: "agent"}
+	clientset := kubefake.NewSimpleClientset(&appsv1.DaemonSet{ObjectMeta: objMeta}, &appsv1.StatefulSet{ObjectMeta: objMeta})
+
+	m := model{view: viewDaemonSets, daemonsets: []appsv1.DaemonSet{{ObjectMeta: objMeta}}}
+	w, ok := m.selectedWorkload(viewDaemonSets)
+	if !ok || w.String() != "daemonset agent" {
+		t.Fatalf("selectedWorkload() = %v, %v", w, ok)
+	}
+	if msg := restartWorkload(clientset, w)(); msg != (workloadRestartedMsg{w}) {
+		t.Fatalf("restartWorkload() = %#v", msg)
+	}
+	ds, _ := clientset.AppsV1().DaemonSets("infra").Get(context.Background(), "agent", metav1.GetOptions{})
+	if ds.Spec.Template.Annotations["kubectl.kubernetes.io/restartedAt"] == "" {
+		t.Fatal("restart didn't stamp the pod template")
+	}
+
+	sts := workload{kind: "statefulset", namespace: "infra", name: "agent"}
+	if msg := deleteWorkload(clientset, sts)(); msg != (workloadDeletedMsg{sts}) {
+		t.Fatalf("deleteWorkload() = %#v", msg)
+	}
+	if _, err := clientset.AppsV1().StatefulSets("infra").Get(context.Background(), "agent", metav1.GetOptions{}); err == nil {
+		t.Fatal("statefulset still exists after delete")
+	}
+}
+
+func TestScaleDownPreviewListsNewestPods(t *testing.T) {
+	created := func(name string, minutesAgo int) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "shop", Name: name, Labels: map[string]string{"app": "web"},
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Duration(minutesAgo) * time.Minute)),
+		}}
+	}
+	other := created("api", 0)
+	other.Labels = map[string]string{"app": "api"}
+	clientset := kubefake.NewSimpleClientset(created("web-old", 60), created("web-new", 1), created("web-mid", 30), other)
+	replicas := int32(3)
+	d := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+	}
+
+	msg, ok := previewScaleDown(clientset, d, 1, viewScaling)().(scaleDownPreviewMsg)
+	if !ok {
+		t.Fatal("previewScaleDown() didn't return a preview")
+	}
+	if strings.Join(msg.victims, ",") != "web-new,web-mid" {
+		t.Fatalf("victims = %v, want [web-new web-mid]", msg.victims)
+	}
+
+	m := model{view: viewLogs}
+	if updated, _ := m.Update(msg); updated.(model).view != viewLogs {
+		t.Fatal("the preview took over the view the user moved on to")
+	}
+
+	m = model{view: viewScaling, textInput: textinput.New()}
+	m.textInput.SetValue("1")
+	updated, _ := m.Update(msg)
+	m = updated.(model)
+	if m.view != viewConfirm || !strings.Contains(m.confirmPrompt, "- web-new") || !strings.Contains(m.confirmPrompt, "from 3 to 1") {
+		t.Fatalf("view = %v, prompt = %q", m.view, m.confirmPrompt)
+	}
+	updated, _ = m.Update(keyPress("n"))
+	m = updated.(model)
+	if m.view != viewScaling || m.textInput.Value() != "" {
+		t.Fatalf("after \"n\": view = %v, input = %q; want an empty scale prompt", m.view, m.textInput.Value())
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestLoggingTransportLogsRequests(t *testing.T) {
+	var buf bytes.Buffer
+	defer func(l *slog.Logger) { logger = l }(logger)
+	logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	transport := loggingTransport{roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusForbidden}, nil
+	})}
+	req, _ := http.NewRequest(http.MethodGet, "https://apiserver/api/v1/namespaces/prod/secrets?limit=500", nil)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	line := buf.String()
+	for _, want := range []string{`"msg":"api request"`, `"url":"/api/v1/namespaces/prod/secrets?limit=500"`, `"status":403`, `"duration":`} {
+		if !strings.Contains(line, want) {
+			t.Errorf("log line %s lacks %s", line, want)
+		}
+	}
+}
+
+func TestListScrollKeepsCursorVisible(t *testing.T) {
+	m := model{view: viewPods}
+	m.viewport.Height = 15 // 10 list rows
+	m.pods = make([]v1.Pod, 30)
+
+	for _, cursor := range []int{12, 29, 20, 3} {
+		m.cursor = cursor
+		updated, _ := m.Update(confirmedMsg{})
+		m = updated.(model)
+		start, end := m.visibleRange(len(m.pods))
+		if m.cursor < start || m.cursor >= end {
+			t.Fatalf("cursor %d outside visible rows [%d, %d)", m.cursor, start, end)
+		}
+		if end-start != 10 {
+			t.Fatalf("showing %d rows, want 10", end-start)
+		}
+	}
+}
+
+func TestEscRetracesNavigation(t *testing.T) {
+	m := model{view: viewNodes}
+	m.setView(viewResourceMenu)
+	m.setView(viewPods)
+	m.setView(viewDetails)
+	m.setView(viewYAML)
+
+	for _, want := range []viewState{viewDetails, viewPods, viewResourceMenu, viewNodes, viewNodes} {
+		updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+		m = updated.(model)
+		if m.view != want {
+			t.Fatalf("view after esc = %v, want %v", m.view, want)
+		}
+	}
+}
+
+func TestGetPodStatus(t *testing.T) {
+	waiting := func(reason string) v1.ContainerStatus {
+		return v1.ContainerStatus{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: reason}}}
+	}
+	running := v1.ContainerStatus{State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}}
+
+	tests := []struct {
+		name string
+		pod  v1.Pod
+		want string
+	}{
+		{"running", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{running}}}, "Running"},
+		{"crash loop", v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{running, waiting("CrashLoopBackOff")}}}, "CrashLoopBackOff"},
+		{"init", v1.Pod{Status: v1.PodStatus{Phase: v1.PodPending, InitContainerStatuses: []v1.ContainerStatus{waiting("ImagePullBackOff")}}}, "Init:ImagePullBackOff"},
+		{"evicted", v1.Pod{Status: v1.PodStatus{Phase: v1.PodFailed, Reason: "Evicted"}}, "Evicted"},
+		{"terminating", v1.Pod{ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{}}, Status: v1.PodStatus{Phase: v1.PodRunning}}, "Terminating"},
+	}
+	for _, tt := range tests {
+		if got := getPodStatus(tt.pod); got != tt.want {
+			t.Errorf("%s: getPodStatus() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestHelpShowsOnlyBindingsForCurrentView(t *testing.T) {
+	m := model{view: viewPods}
+	m.setView(viewDetails)
+	updated, _ := m.Update(keyPress("?"))
+	m = updated.(model)
+	if m.view != viewHelp {
+		t.Fatalf("view after \"?\" = %v, want viewHelp", m.view)
+	}
+
+	help := m.renderHelpView()
+	if !strings.Contains(help, "Delete pod") {
+		t.Errorf("pod details help is missing \"Delete pod\":\n%s", help)
+	}
+	for _, unexpected := range []string{"Scale replicas", "Toggle warnings only"} {
+		if strings.Contains(help, unexpected) {
+			t.Errorf("pod details help lists %q:\n%s", unexpected, help)
+		}
+	}
+}
+
+// keymapPresses returns the key presses a keyHelp's keys stand for, e.g.
+// "up/down, j" is up, down and j.
+func keymapPresses(keys string) []tea.KeyMsg {
+	named := map[string]tea.KeyType{
+		"up": tea.KeyUp, "down": tea.KeyDown, "enter": tea.KeyEnter, "esc": tea.KeyEsc,
+		"backspace": tea.KeyBackspace, "tab": tea.KeyTab, "shift+tab": tea.KeyShiftTab,
+		"ctrl+c": tea.KeyCtrlC, "ctrl+p": tea.KeyCtrlP, "ctrl+y": tea.KeyCtrlY,
+	}
+	var presses []tea.KeyMsg
+	for _, alt := range strings.Split(strings.ReplaceAll(keys, " / ", ", "), ", ") {
+		switch alt {
+		case "up/down":
+			presses = append(presses, tea.KeyMsg{Type: tea.KeyUp}, tea.KeyMsg{Type: tea.KeyDown})
+		case "<number> enter":
+			presses = append(presses, keyPress("2")) // enter is listed on its own
+		case "<text>":
+			presses = append(presses, keyPress("o"))
+		default:
+			if k, ok := named[alt]; ok {
+				presses = append(presses, tea.KeyMsg{Type: k})
+			} else {
+				presses = append(presses, keyPress(alt))
+			}
+		}
+	}
+	return presses
+}
+
+// keymapTestModel is a model in view, opened from source when view is the
+// details, with a few rows in every list so each key has something to act on.
+func keymapTestModel(view, source viewState) model {
+	two := int32(2)
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	meta := func(name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: "shop", Name: name, Labels: map[string]string{"app": "web"}}
+	}
+	m := model{
+		view:               view,
+		ready:              true,
+		styles:             defaultStyles(),
+		textInput:          textinput.New(),
+		topN:               5,
+		notifyRollouts:     true,
+		favoriteNamespaces: []string{"shop", "dev"},
+		resourceTypes:      []string{"Nodes", "Pods", "Deployments", "Services"},
+		viewport:           viewport.New(120, 10),
+	}
+	for i := range 20 {
+		m.details += fmt.Sprintf("Field%d:\tvalue\n", i)
+	}
+	for i, name := range []string{"web-0", "web-1", "web-2"} {
+		m.nodes = append(m.nodes, v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-" + strconv.Itoa(i)}})
+		m.pods = append(m.pods, v1.Pod{ObjectMeta: meta(name), Spec: v1.PodSpec{NodeName: "worker-1", Containers: []v1.Container{{Name: "web"}}},
+			Status: v1.PodStatus{Phase: v1.PodSucceeded}})
+		m.deployments = append(m.deployments, appsv1.Deployment{ObjectMeta: meta(name), Spec: appsv1.DeploymentSpec{Replicas: &two, Selector: selector,
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web", Image: "web:1"}}}}}})
+		m.statefulsets = append(m.statefulsets, appsv1.StatefulSet{ObjectMeta: meta(name), Spec: appsv1.StatefulSetSpec{Replicas: &two, Selector: selector}})
+		m.daemonsets = append(m.daemonsets, appsv1.DaemonSet{ObjectMeta: meta(name), Spec: appsv1.DaemonSetSpec{Selector: selector}})
+		m.services = append(m.services, v1.Service{ObjectMeta: meta(name), Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}}})
+		m.cronJobs = append(m.cronJobs, batchv1.CronJob{ObjectMeta: meta(name)})
+		m.events = append(m.events, v1.Event{ObjectMeta: meta(name), Type: v1.EventTypeWarning,
+			InvolvedObject: v1.ObjectReference{Kind: "Pod", Namespace: "shop", Name: name}})
+		m.namespaces = append(m.namespaces, v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: []string{"shop", "dev", "prod"}[i]}})
+		m.crds = append(m.crds, crdInfo{name: name + ".example.com", kind: "Web", gvr: schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: name}, namespaced: true})
+		m.nodeMap = append(m.nodeMap, nodeMapRow{node: "worker-" + strconv.Itoa(i)})
+	}
+	// Rows, fields and lines above and below, so every direction moves
+	m.cursor, m.detailsField = 1, 4
+	switch view {
+	case viewDetails:
+		m.viewStack = []viewState{source}
+		m.setViewportContent(m.detailsView())
+		m.viewport.SetYOffset(2)
+	case viewLogs, viewYAML, viewDiff:
+		m.viewStack = []viewState{viewPods, viewDetails}
+		m.yamlContent = "kind: Pod\nmetadata:\n  name: web-0\n"
+		m.yamlObject = &m.pods[0]
+		m.setViewportContent(strings.Repeat("line\n", 40))
+		m.viewport.SetYOffset(5)
+	case viewNodes:
+		m.viewStack = []viewState{viewPods}
+	default:
+		m.viewStack = []viewState{viewNodes}
+	}
+	m.scrollToCursor()
+	return m
+}
+
+// TestKeymapKeysAreHandled checks that every key the help lists does something
+// in at least one view its group applies to: it returns a command or changes
+// the screen.
+func TestKeymapKeysAreHandled(t *testing.T) {
+	saved, savedStop := rootCtx, stopAll // q and ctrl+c cancel everything
+	rootCtx, stopAll = context.WithCancel(context.Background())
+	defer func() { rootCtx, stopAll = saved, savedStop }()
+
+	// Handled by the tabs around the model, not by the model itself
+	elsewhere := map[string]bool{"tab": true, "shift+tab": true}
+	// Unstyled test output doesn't show the selected row, so compare it too
+	screen := func(m model) string {
+		return fmt.Sprint(m.View(), m.cursor, m.detailsField, m.viewport.YOffset)
+	}
+
+	type keyContext struct{ view, source viewState }
+	for _, g := range keymap {
+		var contexts []keyContext
+		for v := viewNodes; v <= viewLogGrep; v++ {
+			for s := viewNodes; s <= viewLogGrep; s++ {
+				if listViews[s] && g.applies(v, s) {
+					contexts = append(contexts, keyContext{v, s})
+					if v != viewDetails {
+						break // Only the details depend on the list they came from
+					}
+				}
+			}
+		}
+		if len(contexts) == 0 {
+			t.Errorf("%s: the group applies to no view", g.title)
+			continue
+		}
+		for _, k := range g.keys {
+			for _, press := range keymapPresses(k.keys) {
+				if elsewhere[press.String()] {
+					continue
+				}
+				handled := slices.ContainsFunc(contexts, func(c keyContext) bool {
+					m := keymapTestModel(c.view, c.source)
+					before := screen(m)
+					updated, cmd := m.Update(press)
+					return cmd != nil || screen(updated.(model)) != before
+				})
+				if !handled {
+					t.Errorf("%s: %q (%s) does nothing in any view the group applies to", g.title, press.String(), k.desc)
+				}
+			}
+		}
+	}
+}
+
+func TestSummarizePods(t *testing.T) {
+	ready := v1.PodCondition{Type: v1.PodReady, Status: v1.ConditionTrue}
+	pods := []v1.Pod{
+		{Status: v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{ready}}},
+		{Status: v1.PodStatus{Phase: v1.PodPending}},
+		{Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+		}}},
+		{Status: v1.PodStatus{Phase: v1.PodSucceeded}},
+	}
+	if got, want := summarizePods(pods), "Pods: 4 (2 not ready, 1 crashlooping)"; got != want {
+		t.Fatalf("summarizePods() = %q, want %q", got, want)
+	}
+	if got, want := summarizePods(pods[:1]), "Pods: 1"; got != want {
+		t.Fatalf("summarizePods() = %q, want %q", got, want)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := t.TempDir() + "/kubeview/state.json"
+	if s, err := loadState(path); err != nil || s.View != "" || s.Namespace != "" {
+		t.Fatalf("loadState() on a missing file = %+v, %v", s, err)
+	}
+
+	m := model{view: viewDeployments, selectedNamespace: "prod"}
+	m.setView(viewDetails)
+	if err := saveState(path, m.currentState()); err != nil {
+		t.Fatalf("saveState() error = %v", err)
+	}
+
+	s, err := loadState(path)
+	if err != nil {
+		t.Fatalf("loadState() error = %v", err)
+	}
+	var restored model
+	restored.restoreState(s)
+	if restored.view != viewDeployments || restored.selectedNamespace != "prod" {
+		t.Fatalf("restored view %v in %q, want deployments in \"prod\"", restored.view, restored.selectedNamespace)
+	}
+}
+
+func TestFavoriteNamespacesArePinned(t *testing.T) {
+	m := model{}
+	for _, name := range []string{"default", "dev", "kube-system", "prod"} {
+		ns := v1.Namespace{}
+		ns.Name = name
+		m.namespaces = append(m.namespaces, ns)
+	}
+	m.toggleFavoriteNamespace("prod")
+	m.toggleFavoriteNamespace("dev")
+
+	var got []string
+	for _, ns := range m.namespaces {
+		got = append(got, ns.Name)
+	}
+	if strings.Join(got, ",") != "dev,prod,default,kube-system" {
+		t.Fatalf("namespaces = %v, want favorites first", got)
+	}
+
+	if next := nextFavorite(m.favoriteNamespaces, "prod"); next != "dev" {
+		t.Fatalf("nextFavorite after prod = %q, want dev", next)
+	}
+	m.toggleFavoriteNamespace("prod")
+	if m.isFavoriteNamespace("prod") || len(m.favoriteNamespaces) != 1 {
+		t.Fatalf("favorites after removing prod = %v", m.favoriteNamespaces)
+	}
+}
+
+func TestTabsRouteResultsToTheirCluster(t *testing.T) {
+	app := tabs{tabs: []clusterTab{{name: "a"}, {name: "b"}}}
+
+	cmd := wrapTabCmd(1, func() tea.Msg { return namespacesMsg{namespaces: []v1.Namespace{{}}} })
+	updated, _ := app.Update(cmd())
+	app = updated.(tabs)
+	if len(app.tabs[0].model.namespaces) != 0 || len(app.tabs[1].model.namespaces) != 1 {
+		t.Fatalf("namespaces delivered to the wrong tab: a=%d b=%d", len(app.tabs[0].model.namespaces), len(app.tabs[1].model.namespaces))
+	}
+
+	batch := wrapTabCmd(0, tea.Batch(func() tea.Msg { return confirmedMsg{} }, func() tea.Msg { return confirmedMsg{} }))
+	for _, c := range batch().(tea.BatchMsg) {
+		if msg, ok := c().(tabMsg); !ok || msg.tab != 0 {
+			t.Fatalf("batched command result = %#v, want a tabMsg for tab 0", c())
+		}
+	}
+
+	updated, _ = app.Update(tea.KeyMsg{Type: tea.KeyTab})
+	if updated.(tabs).active != 1 {
+		t.Fatalf("tab did not switch to the next cluster")
+	}
+}
+
+func TestDashboardMetricsAggregation(t *testing.T) {
+	quantities := func(cpu, mem string) v1.ResourceList {
+		return v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse(mem)}
+	}
+	node := func(name, cpu, mem string) *v1.Node {
+		n := &v1.Node{}
+		n.Name = name
+		n.Status.Capacity = quantities(cpu, mem)
+		return n
+	}
+	nodeMetrics := func(name, cpu, mem string) *metricsv1beta1.NodeMetrics {
+		nm := &metricsv1beta1.NodeMetrics{Usage: quantities(cpu, mem)}
+		nm.Name = name
+		return nm
+	}
+	pod := func(ns, name string) *v1.Pod {
+		p := &v1.Pod{}
+		p.Namespace, p.Name = ns, name
+		return p
+	}
+	podMetrics := func(ns, name, cpu, mem string) *metricsv1beta1.PodMetrics {
+		pm := &metricsv1beta1.PodMetrics{Containers: []metricsv1beta1.ContainerMetrics{{Usage: quantities(cpu, mem)}}}
+		pm.Namespace, pm.Name = ns, name
+		return pm
+	}
+
+	clientset := kubefake.NewSimpleClientset(
+		node("node-a", "4", "8Gi"), node("node-b", "4", "8Gi"),
+		pod("prod", "api"), pod("prod", "web"), pod("dev", "job"),
+	)
+	// The metrics fake serves NodeMetrics and PodMetrics as "nodes" and "pods",
+	// which the object tracker can't guess from the kinds, so add them explicitly.
+	metricsClientset := metricsfake.NewSimpleClientset()
+	for _, nm := range []*metricsv1beta1.NodeMetrics{nodeMetrics("node-a", "1", "2Gi"), nodeMetrics("node-b", "3", "2Gi")} {
+		if err := metricsClientset.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("nodes"), nm, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, pm := range []*metricsv1beta1.PodMetrics{podMetrics("prod", "api", "500m", "1Gi"), podMetrics("prod", "web", "250m", "512Mi"), podMetrics("dev", "job", "1", "256Mi")} {
+		if err := metricsClientset.Tracker().Create(metricsv1beta1.SchemeGroupVersion.WithResource("pods"), pm, pm.Namespace); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The same lists fetched one after another, as the reference for the
+	// concurrent fetch
+	serial := func(namespace string, topN int) dashboardMsg {
+		ctx := context.Background()
+		nodes, err := clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodeMetrics, err := metricsClientset.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		podMetrics, err := metricsClientset.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return aggregateDashboard(nodes.Items, nodeMetrics.Items, pods.Items, podMetrics.Items, namespace, topN)
+	}
+
+	got, ok := getDashboardMetrics(clientset, metricsClientset, "", 2)().(dashboardMsg)
+	if !ok {
+		t.Fatalf("getDashboardMetrics() did not return a dashboardMsg")
+	}
+	if want := serial("", 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("concurrent dashboard = %+v, want the serial result %+v", got, want)
+	}
+	if got.cpuPercent != 50 || got.memoryPercent != 25 {
+		t.Errorf("cluster usage = %.1f%% CPU, %.1f%% memory, want 50%% and 25%%", got.cpuPercent, got.memoryPercent)
+	}
+	if len(got.topPodsByCPU) != 2 || got.topPodsByCPU[0].Name != "job" || got.topPodsByCPU[1].Name != "api" {
+		t.Errorf("top pods by CPU = %v, want [job api]", got.topPodsByCPU)
+	}
+	if len(got.topNodesByCPU) != 2 || got.topNodesByCPU[0].Name != "node-b" {
+		t.Errorf("top nodes by CPU = %v, want node-b first", got.topNodesByCPU)
+	}
+
+	scoped := getDashboardMetrics(clientset, metricsClientset, "prod", 5)().(dashboardMsg)
+	if want := serial("prod", 5); !reflect.DeepEqual(scoped, want) {
+		t.Errorf("concurrent prod dashboard = %+v, want the serial result %+v", scoped, want)
+	}
+	if scoped.cpuPercent != 9.375 || len(scoped.topPodsByMemory) != 2 || scoped.topPodsByMemory[0].Name != "api" {
+		t.Errorf("prod dashboard = %.3f%% CPU, top pods by memory %v", scoped.cpuPercent, scoped.topPodsByMemory)
+	}
+}
+
+func TestResourceMenuTypeAhead(t *testing.T) {
+	m := model{view: viewNodes, resourceTypes: []string{"Nodes", "Pods", "Network Policies", "Roles", "RoleBindings", "Node Map"}}
+	m.viewport.Height = 15
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	m = updated.(model)
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("n")},
+		{Type: tea.KeyRunes, Runes: []rune("O")},
+		{Type: tea.KeyRunes, Runes: []rune("d")},
+	} {
+		updated, _ = m.Update(key)
+		m = updated.(model)
+	}
+	if got := strings.Join(m.menuItems(), ","); got != "Nodes,Node Map" {
+		t.Fatalf("menu filtered by %q = %s, want Nodes,Node Map", m.menuFilter, got)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m = updated.(model)
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown}) // Stops at the last match
+	m = updated.(model)
+	if m.menuItems()[m.cursor] != "Node Map" {
+		t.Errorf("cursor on %s, want Node Map", m.menuItems()[m.cursor])
+	}
+
+	for range 3 {
+		updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+		m = updated.(model)
+	}
+	if m.view != viewResourceMenu || len(m.menuItems()) != 6 {
+		t.Errorf("after deleting the filter: view %v with %d items, want the full menu", m.view, len(m.menuItems()))
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("xyz")})
+	m = updated.(model)
+	if list := m.renderResourceMenu(); !strings.Contains(list, "No matching resource types") {
+		t.Errorf("menu with no matches:\n%s", list)
+	}
+}
+
+func TestOpenListRefreshesFreshCache(t *testing.T) {
+	m := model{view: viewResourceMenu, selectedNamespace: "prod"}
+	fetch := func() tea.Msg { return nil }
+
+	updated, _ := m.Update(podsMsg{namespace: "prod", pods: []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "prod", Name: "api"}}}})
+	m = updated.(model)
+	if !m.isFresh(viewPods) {
+		t.Fatalf("pods just fetched from prod are not fresh")
+	}
+	if cmd := m.openList(viewPods, fetch); cmd == nil {
+		t.Fatalf("openList() showed fresh cached pods without refreshing them")
+	}
+	if m.view != viewPods || len(m.pods) != 1 {
+		t.Fatalf("openList() = view %v with %d pods, want the cached pods", m.view, len(m.pods))
+	}
+
+	m.selectedNamespace = "dev"
+	if m.isFresh(viewPods) {
+		t.Fatalf("pods cached for prod are fresh for dev")
+	}
+
+	// A reply for prod landing after the switch to dev stays stamped for prod
+	updated, _ = m.Update(podsMsg{namespace: "prod"})
+	m = updated.(model)
+	if m.isFresh(viewPods) {
+		t.Fatalf("a late prod reply marked the pods fresh for dev")
+	}
+}
+
+func TestCreateNamespaceRejectsInvalidNames(t *testing.T) {
+	for _, name := range []string{"", "My-Namespace", "-leading", "has_underscore"} {
+		msg := createNamespace(nil, name)()
+		if _, ok := msg.(errMsg); !ok {
+			t.Errorf("createNamespace(%q) = %T, want errMsg", name, msg)
+		}
+	}
+}
+
+func TestDeleteNamespaceRequiresTypedName(t *testing.T) {
+	m := model{view: viewNamespaces, namespaces: []v1.Namespace{{ObjectMeta: metav1.ObjectMeta{Name: "prod"}}}, cursor: 1, textInput: textinput.New()}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("d")})
+	m = updated.(model)
+	if m.view != viewDeleteNamespace || m.deletingNamespace != "prod" {
+		t.Fatalf("view = %v, deleting %q; want delete prompt for prod", m.view, m.deletingNamespace)
+	}
+	m.textInput.SetValue("y")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	if m.err == nil || m.view != viewDeleteNamespace {
+		t.Errorf("mismatched name should be rejected, got view %v err %v", m.view, m.err)
+	}
+}
+
+func TestCronJobSuspendToggle(t *testing.T) {
+	cj := &batchv1.CronJob{ObjectMeta: metav1.ObjectMeta{Name: "backup", Namespace: "default"}, Spec: batchv1.CronJobSpec{Schedule: "0 * * * *"}}
+	clientset := kubefake.NewSimpleClientset(cj)
+	m := model{view: viewCronJobs, cronJobs: []batchv1.CronJob{*cj}}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if cmd == nil {
+		t.Fatal("expected a suspend command")
+	}
+	if msg := setCronJobSuspend(clientset, "default", "backup", true)(); msg != (cronJobSuspendedMsg{}) {
+		t.Fatalf("setCronJobSuspend returned %v", msg)
+	}
+	got, _ := clientset.BatchV1().CronJobs("default").Get(context.Background(), "backup", metav1.GetOptions{})
+	if !cronJobSuspended(*got) {
+		t.Error("cronjob should be suspended")
+	}
+}
+
+func TestYAMLViewTogglesJSON(t *testing.T) {
+	pod := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+	m := model{view: viewDetails}
+	updated, _ := m.Update(yamlMsg{yaml: "metadata:\n  name: web\n", obj: pod})
+	m = updated.(model)
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(model)
+	if !m.yamlAsJSON || !strings.Contains(m.yamlContent, `"name": "web"`) {
+		t.Fatalf("expected indented JSON, got %q", m.yamlContent)
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	m = updated.(model)
+	if m.yamlAsJSON || !strings.Contains(m.yamlContent, "name: web") {
+		t.Errorf("expected YAML again, got %q", m.yamlContent)
+	}
+}
+
+func TestApplyDocumentsCreatesAndUpdates(t *testing.T) {
+	cmGVK := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(cmGVK, meta.RESTScopeNamespace)
+	// Server-side apply needs a tracker that manages fields
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(cmGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(cmGVK.GroupVersion().WithKind("ConfigMapList"), &unstructured.UnstructuredList{})
+	tracker := k8stesting.NewFieldManagedObjectTracker(scheme, unstructured.UnstructuredJSONScheme, managedfields.NewDeducedTypeConverter())
+	existing := &unstructured.Unstructured{Object: map[string]any{"data": map[string]any{"set-elsewhere": "1"}}}
+	existing.SetGroupVersionKind(cmGVK)
+	existing.SetNamespace("team-a")
+	existing.SetName("old")
+	if err := tracker.Add(existing); err != nil {
+		t.Fatal(err)
+	}
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme)
+	dynamicClient.PrependReactor("*", "*", k8stesting.ObjectReaction(tracker))
+
+	manifest := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: old
+data:
+  k: v
+---
+{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "new"}}
+---
+apiVersion: v1
+kind: Widget
+metadata:
+  name: unknown
+`
+	results := applyDocuments(mapper, dynamicClient, strings.NewReader(manifest), "team-a")
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3: %+v", len(results), results)
+	}
+	if results[0].action != "configured" || results[0].err != nil {
+		t.Errorf("existing object: %+v", results[0])
+	}
+	if results[1].action != "created" || results[1].err != nil {
+		t.Errorf("new object: %+v", results[1])
+	}
+	if results[2].err == nil {
+		t.Errorf("unknown kind should fail: %+v", results[2])
+	}
+	cmGVR := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	if _, err := dynamicClient.Resource(cmGVR).Namespace("team-a").Get(context.Background(), "new", metav1.GetOptions{}); err != nil {
+		t.Errorf("new object not created in the selected namespace: %v", err)
+	}
+	old, err := dynamicClient.Resource(cmGVR).Namespace("team-a").Get(context.Background(), "old", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _, _ := unstructured.NestedStringMap(old.Object, "data"); data["k"] != "v" || data["set-elsewhere"] != "1" {
+		t.Errorf("data after apply = %v, want the manifest's key added and the other kept", data)
+	}
+}
+
+func TestReplicaKeysNeverScaleBelowZero(t *testing.T) {
+	zero := int32(0)
+	m := model{view: viewDeployments, deployments: []appsv1.Deployment{{ObjectMeta: metav1.ObjectMeta{Name: "web"}, Spec: appsv1.DeploymentSpec{Replicas: &zero}}}}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")}); cmd != nil {
+		t.Error("'-' at zero replicas should not scale")
+	}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("+")}); cmd == nil {
+		t.Error("'+' should scale up")
+	}
+
+	// Removing a replica confirms like typing a lower count does
+	two := int32(2)
+	m.deployments[0].Spec.Replicas = &two
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("-")})
+	if cmd == nil {
+		t.Fatal("'-' should preview the scale down")
+	}
+	updated, _ = updated.(model).Update(scaleDownPreviewMsg{name: "web", from: 2, to: 1, victims: []string{"web-2"}, view: viewDeployments})
+	if m := updated.(model); m.view != viewConfirm || !strings.Contains(m.confirmPrompt, "web-2") {
+		t.Errorf("after '-': view %v, prompt %q, want a confirmation naming web-2", m.view, m.confirmPrompt)
+	}
+}
+
+func TestWorkloadPodDrillDown(t *testing.T) {
+	d := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"},
+		Spec:       appsv1.DeploymentSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}},
+	}
+	m := model{view: viewDeployments, deployments: []appsv1.Deployment{d}}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = updated.(model)
+	if m.view != viewPods || cmd == nil {
+		t.Fatalf("view = %v, want filtered pods view with a fetch", m.view)
+	}
+	if f := m.podFilter; f == nil || f.selector != "app=web" || f.namespace != "shop" {
+		t.Fatalf("podFilter = %+v", m.podFilter)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(model)
+	if m.view != viewDeployments || m.podFilter != nil {
+		t.Errorf("esc should return to deployments and drop the filter, got view %v filter %+v", m.view, m.podFilter)
+	}
+}
+
+func TestPodControllerDrillUp(t *testing.T) {
+	isController := true
+	rs := &appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{
+		Name: "web-5d8f", Namespace: "shop",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "Deployment", Name: "web", Controller: &isController}},
+	}}
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name: "web-5d8f-abc", Namespace: "shop",
+		OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f", Controller: &isController}},
+	}}
+	msg := getController(kubefake.NewSimpleClientset(rs), pod)()
+	want := controllerMsg{kind: "Deployment", namespace: "shop", name: "web"}
+	if msg != want {
+		t.Fatalf("getController = %v, want %v", msg, want)
+	}
+
+	m := model{view: viewPods, pods: []v1.Pod{pod}}
+	updated, _ := m.Update(msg)
+	m = updated.(model)
+	deployments := []appsv1.Deployment{
+		{ObjectMeta: metav1.ObjectMeta{Name: "api", Namespace: "shop"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "shop"}},
+	}
+	updated, _ = m.Update(deploymentsMsg{deployments: deployments})
+	m = updated.(model)
+	if m.view != viewDeployments || m.cursor != 1 {
+		t.Errorf("view = %v, cursor = %d; want the web deployment selected", m.view, m.cursor)
+	}
+
+	// A pod from another namespace, e.g. on a node, switches to its namespace
+	m = model{view: viewPods, selectedNamespace: "billing", pods: []v1.Pod{pod}}
+	updated, _ = m.Update(msg)
+	m = updated.(model)
+	if m.selectedNamespace != "shop" {
+		t.Errorf("selectedNamespace = %q, want the controller's namespace", m.selectedNamespace)
+	}
+	updated, _ = m.Update(deploymentsMsg{deployments: deployments[:1]})
+	m = updated.(model)
+	if m.err == nil || !strings.Contains(m.err.Error(), "Deployment shop/web is not in the list") {
+		t.Errorf("a missing controller should be reported, got %v", m.err)
+	}
+}
+
+func TestNodePodsFilter(t *testing.T) {
+	m := model{view: viewNodes, nodes: []v1.Node{{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}}}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+	m = updated.(model)
+	if m.view != viewPods || m.podFilter == nil || m.podFilter.field != "spec.nodeName=worker-1" {
+		t.Fatalf("view = %v, filter = %+v; want pods on worker-1", m.view, m.podFilter)
+	}
+}
+
+func TestDashboardRequestsAgainstCapacity(t *testing.T) {
+	node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}}
+	node.Status.Capacity = v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")}
+	pod := func(phase v1.PodPhase, cpu, mem string) v1.Pod {
+		p := v1.Pod{}
+		p.Spec.NodeName = "node-a"
+		p.Status.Phase = phase
+		p.Spec.Containers = []v1.Container{{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+			v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse(mem),
+		}}}}
+		return p
+	}
+	pods := []v1.Pod{pod(v1.PodRunning, "1", "2Gi"), pod(v1.PodRunning, "1", "2Gi"), pod(v1.PodSucceeded, "2", "4Gi")}
+
+	got := aggregateDashboard([]v1.Node{node}, nil, pods, nil, "", 5).totals
+	m := model{}
+	if cpu := m.cpuAgainst(got.cpuRequests, got.cpuCapacity); cpu != "2000m / 4000m (50%)" {
+		t.Errorf("CPU requests = %q", cpu)
+	}
+	if mem := m.memoryAgainst(got.memoryRequests, got.memoryCapacity); !strings.HasSuffix(mem, "(50%)") {
+		t.Errorf("memory requests = %q", mem)
+	}
+
+	m.wholeUnits = true
+	if cpu := m.cpuAgainst(got.cpuRequests, got.cpuCapacity); cpu != "2 / 4 (50%)" {
+		t.Errorf("CPU requests in cores = %q", cpu)
+	}
+	if mem := m.memoryAgainst(got.memoryRequests, got.memoryCapacity); mem != "4Gi / 8Gi (50%)" {
+		t.Errorf("memory requests in GiB = %q", mem)
+	}
+}
+
+func TestCleanUpFinishedPods(t *testing.T) {
+	pod := func(name string, phase v1.PodPhase, reason string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "batch", Name: name}, Status: v1.PodStatus{Phase: phase, Reason: reason}}
+	}
+	pods := []*v1.Pod{pod("worker", v1.PodRunning, ""), pod("report-1", v1.PodSucceeded, ""), pod("report-2", v1.PodFailed, "Evicted"), pod("import", v1.PodFailed, "")}
+	clientset := kubefake.NewSimpleClientset(pods[0], pods[1], pods[2], pods[3])
+
+	m := model{view: viewPods, selectedNamespace: "batch", details: "Name: billing-api"} // Details opened earlier
+	m.viewport.Height = 20
+	for _, p := range pods {
+		m.pods = append(m.pods, *p)
+	}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")})
+	m = updated.(model)
+	if m.view != viewConfirm || !strings.Contains(m.confirmPrompt, "Delete 3 ") || !strings.Contains(m.confirmPrompt, "in batch") {
+		t.Fatalf("view %v, prompt %q; want a confirmation for 3 pods in batch", m.view, m.confirmPrompt)
+	}
+	if got := m.confirmBackdrop(); strings.Contains(got, "billing-api") || !strings.Contains(got, "report-1") {
+		t.Fatalf("confirmation backdrop = %q, want the pods list instead of stale details", got)
+	}
+
+	if msg, ok := deletePods(clientset, finishedPods(m.pods))().(podsDeletedMsg); !ok || msg.deleted != 3 {
+		t.Fatalf("deletePods = %+v, want 3 deleted", msg)
+	}
+	left, _ := clientset.CoreV1().Pods("batch").List(context.Background(), metav1.ListOptions{})
+	if len(left.Items) != 1 || left.Items[0].Name != "worker" {
+		t.Errorf("pods left = %v, want only the running worker", left.Items)
+	}
+
+	m = model{view: viewPods, pods: []v1.Pod{*pods[0]}}
+	if _, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("C")}); cmd == nil {
+		t.Errorf("no finished pods: want an explanation instead of a silent no-op")
+	}
+}
+
+func TestNodeDetailsShowLivePressure(t *testing.T) {
+	node := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-1"}}
+	node.Status.Allocatable = v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi"), v1.ResourcePods: resource.MustParse("110")}
+	usage := func(cpu string) map[string]metricsv1beta1.NodeMetrics {
+		return map[string]metricsv1beta1.NodeMetrics{"worker-1": {Usage: v1.ResourceList{v1.ResourceCPU: resource.MustParse(cpu), v1.ResourceMemory: resource.MustParse("2Gi")}}}
+	}
+	allocations := map[string]nodeAllocation{"worker-1": {pods: 12, cpuRequests: resource.MustParse("3800m"), memoryRequests: resource.MustParse("4Gi")}}
+
+	m := model{view: viewNodes, nodes: []v1.Node{node}, nodeMetrics: usage("1"), nodeAllocations: allocations, styles: defaultStyles()}
+	m.viewport.Height = 40
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	details := strings.Join(strings.Fields(m.details), " ")
+	for _, want := range []string{"CPU allocatable 4000m used 1000m 25% requested 3800m 95%", "requested 4096Mi 50%", "Pods scheduled 12 / 110"} {
+		if !strings.Contains(details, want) {
+			t.Errorf("node details don't show %q:\n%s", want, m.details)
+		}
+	}
+
+	other := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-0"}}
+	updated, _ = m.Update(nodesMsg{nodes: []v1.Node{other, node}, metrics: usage("2"), allocations: allocations})
+	m = updated.(model)
+	if m.nodes[m.cursor].Name != "worker-1" || !strings.Contains(strings.Join(strings.Fields(m.details), " "), "used 2000m 50%") {
+		t.Errorf("refreshed details on %s:\n%s", m.nodes[m.cursor].Name, m.details)
+	}
+}
+
+func TestPreviousLogsExplainRestart(t *testing.T) {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0"}}
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{
+		{Name: "sidecar"},
+		{Name: "web", RestartCount: 4, LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{
+			Reason: "OOMKilled", ExitCode: 137, FinishedAt: metav1.NewTime(time.Now().Add(-5 * time.Minute)),
+		}}},
+	}
+	msg, ok := getPreviousLogs(kubefake.NewSimpleClientset(&pod), pod)().(logsMsg)
+	if !ok {
+		t.Fatalf("getPreviousLogs returned %T, want logsMsg", msg)
+	}
+	header := strings.SplitN(msg.logs, "\n", 2)[0]
+	for _, want := range []string{"Container web", "restarted 4 time(s)", "OOMKilled", "exit code 137", "5m ago"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("header %q doesn't mention %q", header, want)
+		}
+	}
+	if strings.Contains(msg.logs, "sidecar") || !strings.Contains(msg.logs, "fake logs") {
+		t.Errorf("logs should hold only the restarted container's previous logs:\n%s", msg.logs)
+	}
+
+	pod.Status.ContainerStatuses = pod.Status.ContainerStatuses[:1]
+	msg = getPreviousLogs(kubefake.NewSimpleClientset(&pod), pod)().(logsMsg)
+	if !strings.Contains(msg.logs, "has restarted") {
+		t.Errorf("pod without restarts: %q", msg.logs)
+	}
+}
+
+func TestRolloutNotifiesWhenDone(t *testing.T) {
+	m := model{view: viewDeployments, notifyRollouts: true}
+	updated, _ := m.Update(workloadRestartedMsg{workload{kind: "deployment", namespace: "shop", name: "web"}})
+	m = updated.(model)
+	if !m.watchedRollouts["shop/web"] {
+		t.Fatalf("restarting a deployment didn't start watching its rollout")
+	}
+
+	replicas := int32(2)
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web", Generation: 2}, Spec: appsv1.DeploymentSpec{Replicas: &replicas}}
+	d.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2}
+	updated, cmd := m.Update(rolloutPolledMsg{namespace: "shop", name: "web", deployment: d})
+	m = updated.(model)
+	if cmd == nil || m.rolloutNotice != "" {
+		t.Fatalf("rollout in progress: notice = %q, want none and another poll", m.rolloutNotice)
+	}
+
+	d.Status = appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2}
+	m.view = viewPods // Navigated away meanwhile
+	updated, _ = m.Update(rolloutPolledMsg{namespace: "shop", name: "web", deployment: d})
+	m = updated.(model)
+	if m.watchedRollouts["shop/web"] || !strings.Contains(m.footerView(), "shop/web rolled out") {
+		t.Errorf("finished rollout: footer %q, still watched = %v", m.footerView(), m.watchedRollouts["shop/web"])
+	}
+	if m.ready = true; !strings.HasPrefix(m.View(), bell) {
+		t.Errorf("the next frame should ring the bell")
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("j")})
+	if updated.(model).rolloutNotice != "" {
+		t.Errorf("notice not cleared by the next key")
+	}
+
+	d.Status.Conditions = []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Status: v1.ConditionFalse, Reason: "ProgressDeadlineExceeded", Message: "ReplicaSet \"web-2\" has timed out progressing."}}
+	d.Status.UpdatedReplicas = 1
+	if notice, ended, failed := rolloutOutcome(d); !ended || !failed || !strings.Contains(notice, "timed out") {
+		t.Errorf("rolloutOutcome(deadline exceeded) = %q, %v, %v", notice, ended, failed)
+	}
+
+	m = model{notifyRollouts: false}
+	if m.watchRollout("shop", "web") != nil {
+		t.Errorf("watched a rollout with notifications off")
+	}
+}
+
+func TestDescribeRunsKubectlForSelected(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "kubectl"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	m := model{view: viewDetails, viewStack: []viewState{viewPods}, kubeconfig: "/tmp/kc", kubeContext: "staging"}
+	m.pods = []v1.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0"}}}
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("D")})
+	if cmd == nil {
+		t.Fatal("D returned no command")
+	}
+	msg, ok := cmd().(execProcessMsg)
+	if !ok {
+		t.Fatalf("D produced %T, want execProcessMsg", cmd())
+	}
+	got := strings.Join(msg.cmd.Args[4:], " ") // After sh -c, the script and $0
+	if want := "--kubeconfig /tmp/kc --context staging describe Pod web-0 --namespace shop"; got != want {
+		t.Errorf("kubectl args = %q, want %q", got, want)
+	}
+
+	m.viewStack = []viewState{viewCRDs, viewCustomResources}
+	m.selectedCRD = crdInfo{name: "widgets.example.com"}
+	cr := unstructured.Unstructured{}
+	cr.SetName("blue")
+	m.customResources = []unstructured.Unstructured{cr}
+	ref, _ := m.describeTarget(m.detailsSource())
+	if ref.kind != "widgets.example.com" || ref.name != "blue" {
+		t.Errorf("custom resource describe target = %+v", ref)
+	}
+
+	// Tabs start the process themselves so tea's exec message isn't wrapped
+	tb := tabs{tabs: []clusterTab{{model: m}}}
+	if _, cmd := tb.Update(tabMsg{tab: 0, msg: msg}); cmd == nil {
+		t.Errorf("tabs didn't start the kubectl process")
+	}
+}
+
+func TestJumpToNamespaceOfSelected(t *testing.T) {
+	clientset := kubefake.NewSimpleClientset(
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "api-0"}},
+		&v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "api-1"}},
+	)
+	m := model{view: viewPods, pods: []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "payments", Name: "api-1"}},
+	}}
+	m.cursor = 1
+	if !strings.Contains(m.footerView(), "(z)") {
+		t.Errorf("footer %q doesn't offer z in an all-namespaces list", m.footerView())
+	}
+
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	m = updated.(model)
+	if m.selectedNamespace != "payments" || cmd == nil {
+		t.Fatalf("selectedNamespace = %q, want payments with a refetch", m.selectedNamespace)
+	}
+
+	pods, _ := clientset.CoreV1().Pods("payments").List(context.Background(), metav1.ListOptions{})
+	updated, _ = m.Update(podsMsg{pods: pods.Items})
+	m = updated.(model)
+	if m.pods[m.cursor].Name != "api-1" {
+		t.Errorf("cursor on %s, want api-1 to stay selected", m.pods[m.cursor].Name)
+	}
+
+	updated, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("z")})
+	if updated.(model).selectedNamespace != "payments" || cmd != nil {
+		t.Errorf("z in a namespaced list should do nothing")
+	}
+
+	pvc := func(namespace, name string) v1.PersistentVolumeClaim {
+		return v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	m = model{view: viewPVCs, pvcs: []v1.PersistentVolumeClaim{pvc("a", "x"), pvc("b", "y")}, cursor: 1}
+	updated, _ = m.Update(keyPress("z"))
+	updated, _ = updated.(model).Update(pvcsMsg{pvcs: []v1.PersistentVolumeClaim{pvc("b", "w"), pvc("b", "y")}, namespace: "b"})
+	if m = updated.(model); m.err != nil || m.cursor != 1 {
+		t.Errorf("after z in PVCs: cursor %d, error %v; want b/y selected", m.cursor, m.err)
+	}
+
+	event := func(namespace, name string) v1.Event {
+		return v1.Event{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+	}
+	m = model{view: viewEvents, events: []v1.Event{event("a", "x.1"), event("b", "y.1")}, cursor: 1}
+	updated, _ = m.Update(keyPress("z"))
+	updated, _ = updated.(model).Update(eventsMsg{events: []v1.Event{event("b", "w.1"), event("b", "y.1")}})
+	if m = updated.(model); m.err != nil || m.cursor != 1 || m.rowTarget != nil {
+		t.Errorf("after z in Events: cursor %d, error %v, target %v; want b/y.1 selected", m.cursor, m.err, m.rowTarget)
+	}
+}
+
+func TestWholeUnitsRounding(t *testing.T) {
+	for in, want := range map[string]string{"64000m": "64", "250m": "0.25", "1500m": "1.5", "3333m": "3.33"} {
+		q := resource.MustParse(in)
+		if got := formatCores(&q); got != want {
+			t.Errorf("formatCores(%s) = %q, want %q", in, got, want)
+		}
+	}
+	for in, want := range map[string]string{"131072Mi": "128Gi", "256Mi": "0.25Gi", "1536Mi": "1.5Gi", "10000Mi": "9.8Gi"} {
+		q := resource.MustParse(in)
+		if got := formatGiBMemory(&q); got != want {
+			t.Errorf("formatGiBMemory(%s) = %q, want %q", in, got, want)
+		}
+	}
+
+	m := model{view: viewNodes}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("U")})
+	if !updated.(model).wholeUnits {
+		t.Errorf("U didn't switch to cores/GiB")
+	}
+}
+
+func TestPodsListShowsUsageAgainstRequests(t *testing.T) {
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "api"}}
+	pod.Spec.Containers = []v1.Container{{Resources: v1.ResourceRequirements{Requests: v1.ResourceList{
+		v1.ResourceCPU: resource.MustParse("200m"), v1.ResourceMemory: resource.MustParse("128Mi"),
+	}}}}
+	usage := metricsv1beta1.PodMetrics{Containers: []metricsv1beta1.ContainerMetrics{{Usage: v1.ResourceList{
+		v1.ResourceCPU: resource.MustParse("120m"), v1.ResourceMemory: resource.MustParse("64Mi"),
+	}}}}
+	m := model{view: viewPods, pods: []v1.Pod{pod}, podMetrics: map[string]metricsv1beta1.PodMetrics{"api": usage}, styles: defaultStyles()}
+	out := m.renderPodsList()
+	for _, want := range []string{"120m/200m", "60%", "64Mi/128Mi", "50%"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("pods list missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestNodePressureConditions(t *testing.T) {
+	node := v1.Node{Status: v1.NodeStatus{Conditions: []v1.NodeCondition{
+		{Type: v1.NodeReady, Status: v1.ConditionTrue},
+		{Type: v1.NodeMemoryPressure, Status: v1.ConditionFalse},
+		{Type: v1.NodeDiskPressure, Status: v1.ConditionTrue},
+		{Type: v1.NodePIDPressure, Status: v1.ConditionTrue},
+	}}}
+	if got := strings.Join(nodePressureConditions(node), ","); got != "DiskPressure,PIDPressure" {
+		t.Errorf("nodePressureConditions() = %q", got)
+	}
+	node.Labels = map[string]string{"node-role.kubernetes.io/worker": "", "node-role.kubernetes.io/control-plane": ""}
+	if got := getNodeRoles(node); got != "control-plane,worker" {
+		t.Errorf("getNodeRoles() = %q", got)
+	}
+}
+
+func TestPodDetailsShowContainerStates(t *testing.T) {
+	pod := v1.Pod{Spec: v1.PodSpec{Containers: []v1.Container{{Name: "app"}}}}
+	pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+		Name:                 "app",
+		RestartCount:         4,
+		State:                v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 40s restarting failed container"}},
+		LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 137, Signal: 9}},
+	}}
+	m := model{styles: defaultStyles()}
+	out := m.formatPodDetails(pod, metricsv1beta1.PodMetrics{}, false)
+	for _, want := range []string{"Restarts:\t4", "CrashLoopBackOff", "back-off 40s", "Last State", "Exit Code:\t137", "Signal:\t9"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("pod details missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestPodDetailsShowInitAndEphemeralContainers(t *testing.T) {
+	pod := v1.Pod{Spec: v1.PodSpec{
+		InitContainers:      []v1.Container{{Name: "migrate", Image: "migrate:1"}},
+		Containers:          []v1.Container{{Name: "app", Image: "app:1"}},
+		EphemeralContainers: []v1.EphemeralContainer{{EphemeralContainerCommon: v1.EphemeralContainerCommon{Name: "debugger", Image: "busybox"}}},
+	}}
+	pod.Status.InitContainerStatuses = []v1.ContainerStatus{{
+		Name:  "migrate",
+		State: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "Error", ExitCode: 1}},
+	}}
+	m := model{styles: defaultStyles()}
+	out := m.formatPodDetails(pod, metricsv1beta1.PodMetrics{}, false)
+	for _, want := range []string{"Init Containers", "migrate", "Exit Code:\t1", "Ephemeral Containers", "debugger"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("pod details missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatEnvValue(t *testing.T) {
+	tests := []struct {
+		env  v1.EnvVar
+		want string
+	}{
+		{v1.EnvVar{Name: "MODE", Value: "prod"}, "prod"},
+		{v1.EnvVar{Name: "PASS", ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "db"}, Key: "password"}}}, "<secret db/password>"},
+		{v1.EnvVar{Name: "CFG", ValueFrom: &v1.EnvVarSource{ConfigMapKeyRef: &v1.ConfigMapKeySelector{LocalObjectReference: v1.LocalObjectReference{Name: "app"}, Key: "level"}}}, "<configmap app/level>"},
+		{v1.EnvVar{Name: "IP", ValueFrom: &v1.EnvVarSource{FieldRef: &v1.ObjectFieldSelector{FieldPath: "status.podIP"}}}, "<field status.podIP>"},
+	}
+	for _, tt := range tests {
+		if got := formatEnvValue(tt.env); got != tt.want {
+			t.Errorf("formatEnvValue(%s) = %q, want %q", tt.env.Name, got, tt.want)
+		}
+	}
+}
+
+func TestPodDetailsMapVolumesToMounts(t *testing.T) {
+	pod := v1.Pod{Spec: v1.PodSpec{
+		Containers: []v1.Container{{Name: "app", VolumeMounts: []v1.VolumeMount{{Name: "config", MountPath: "/etc/app", ReadOnly: true}}}},
+		Volumes: []v1.Volume{
+			{Name: "config", VolumeSource: v1.VolumeSource{ConfigMap: &v1.ConfigMapVolumeSource{LocalObjectReference: v1.LocalObjectReference{Name: "app-config"}}}},
+			{Name: "data", VolumeSource: v1.VolumeSource{PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{ClaimName: "app-data"}}},
+		},
+	}}
+	m := model{styles: defaultStyles()}
+	out := m.formatPodDetails(pod, metricsv1beta1.PodMetrics{}, false)
+	for _, want := range []string{"config:\tConfigMap app-config", "mounted at app:/etc/app (ro)", "data:\tPVC app-data", "not mounted"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("pod details missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestMarkAndDiffDeployments(t *testing.T) {
+	staging := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "staging"}}
+	prod := appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "prod"}}
+	m := model{view: viewDeployments, deployments: []appsv1.Deployment{staging, prod}}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m = updated.(model)
+	if m.diffMark == nil || m.diffMark.namespace != "staging" {
+		t.Fatalf("diffMark = %+v, want staging/web", m.diffMark)
+	}
+	m.cursor = 1
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	m = updated.(model)
+	if cmd == nil || m.diffMark != nil {
+		t.Errorf("second mark should start a diff and clear the mark, got cmd %v mark %+v", cmd, m.diffMark)
+	}
+}
+
+func TestPrintOnceRejectsUnknownResource(t *testing.T) {
+	err := printOnce(model{}, "widgets", &strings.Builder{})
+	if err == nil || !strings.Contains(err.Error(), "pods") {
+		t.Errorf("printOnce(widgets) error = %v, want the list of known resources", err)
+	}
+}
+
+func TestNewModelReportsUnreadableKubeconfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing")
+	_, err := newModel(path, "", defaultStyles(), 5)
+	if err == nil || !strings.Contains(err.Error(), "could not load kubeconfig at "+path) {
+		t.Fatalf("expected error naming the kubeconfig path, got %v", err)
+	}
+
+	config := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(config, []byte(`apiVersion: v1
+kind: Config
+clusters:
+- name: c
+  cluster: {server: "https://127.0.0.1:6443"}
+users:
+- name: u
+contexts:
+- name: real
+  context: {cluster: c, user: u}
+current-context: real
+`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newModel(config, "", defaultStyles(), 5); err != nil {
+		t.Fatalf("valid kubeconfig: %v", err)
+	}
+	_, err = newModel(config, "typo", defaultStyles(), 5)
+	if err == nil || !strings.Contains(err.Error(), `could not load context "typo" from kubeconfig at `+config) {
+		t.Fatalf("expected error naming the context, got %v", err)
+	}
+}
+
+func TestServicePortForward(t *testing.T) {
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "kubectl"), []byte("#!/bin/sh\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin)
+
+	svc := v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{
+			{Name: "http", Port: 80},
+			{Name: "metrics", Port: 9090},
+		}},
+	}
+	m := model{view: viewServices, services: []v1.Service{svc}, kubeContext: "staging", textInput: textinput.New()}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("f")})
+	m = updated.(model)
+	if m.view != viewPortForward || m.textInput.Value() != "8080:80" {
+		t.Fatalf("view %v with input %q, want the port prompt suggesting 8080:80", m.view, m.textInput.Value())
+	}
+
+	m.textInput.SetValue("9000:metrics")
+	updated, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	m = updated.(model)
+	if m.view != viewServices || cmd == nil {
+		t.Fatalf("enter left view %v, want the services list and a command", m.view)
+	}
+	msg, ok := cmd().(execProcessMsg)
+	if !ok {
+		t.Fatalf("enter produced %T, want execProcessMsg", cmd())
+	}
+	got := strings.Join(msg.cmd.Args[6:], " ") // After sh -c, the script, $0 and the two banner arguments
+	if want := "--context staging port-forward svc/web 9000:9090 --namespace shop"; got != want {
+		t.Errorf("kubectl args = %q, want %q", got, want)
+	}
+
+	for input, want := range map[string]string{
+		"8080":      "use local:port",
+		"0:80":      "invalid local port",
+		"8080:grpc": `no port "grpc"`,
+	} {
+		if _, _, err := parsePortForward(input, svc); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("parsePortForward(%q) = %v, want error containing %q", input, err, want)
+		}
+	}
+}
+
+func TestOOMKilledContainersFlagged(t *testing.T) {
+	limit := resource.MustParse("256Mi")
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "api-0"},
+		Spec: v1.PodSpec{Containers: []v1.Container{
+			{Name: "api", Resources: v1.ResourceRequirements{Limits: v1.ResourceList{v1.ResourceMemory: limit}}},
+			{Name: "sidecar"},
+		}},
+		Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: []v1.ContainerStatus{
+			{Name: "api", RestartCount: 2, State: v1.ContainerState{Running: &v1.ContainerStateRunning{}},
+				LastTerminationState: v1.ContainerState{Terminated: &v1.ContainerStateTerminated{Reason: "OOMKilled", ExitCode: 137}}},
+			{Name: "sidecar", State: v1.ContainerState{Running: &v1.ContainerStateRunning{}}},
+		}},
+	}
+	if got := oomKilledContainers(pod); len(got) != 1 || got[0].Name != "api" {
+		t.Fatalf("oomKilledContainers = %+v, want only api", got)
+	}
+
+	m := model{view: viewPods, pods: []v1.Pod{pod}, styles: defaultStyles()}
+	if list := m.renderPodsList(); !strings.Contains(list, "Running OOM") {
+		t.Errorf("pod list doesn't badge the OOM kill:\n%s", list)
+	}
+
+	metrics := metricsv1beta1.PodMetrics{Containers: []metricsv1beta1.ContainerMetrics{
+		{Name: "api", Usage: v1.ResourceList{v1.ResourceMemory: resource.MustParse("192Mi")}},
+	}}
+	details := m.formatPodDetails(pod, metrics, true)
+	if want := "container api, limit 256Mi, using 192Mi now (75%)"; !strings.Contains(details, want) {
+		t.Errorf("details missing %q:\n%s", want, details)
+	}
+	if strings.Contains(details, "container sidecar") {
+		t.Errorf("details flag a container that wasn't OOM killed:\n%s", details)
+	}
+}
+
+func TestDaemonSetNodeCoverage(t *testing.T) {
+	ds := appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "agent", Generation: 2},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}},
+			Template: v1.PodTemplateSpec{Spec: v1.PodSpec{
+				NodeSelector: map[string]string{"kubernetes.io/os": "linux"},
+				Tolerations:  []v1.Toleration{{Key: "node-role.kubernetes.io/control-plane", Operator: v1.TolerationOpExists}},
+			}},
+		},
+		Status: appsv1.DaemonSetStatus{ObservedGeneration: 2, DesiredNumberScheduled: 4, CurrentNumberScheduled: 3, NumberReady: 2, UpdatedNumberScheduled: 4, NumberAvailable: 2, NumberUnavailable: 2},
+	}
+	linux := map[string]string{"kubernetes.io/os": "linux"}
+	nodes := []runtime.Object{
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "cp", Labels: linux}, Spec: v1.NodeSpec{Taints: []v1.Taint{{Key: "node-role.kubernetes.io/control-plane", Effect: v1.TaintEffectNoSchedule}}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "gpu", Labels: linux}, Spec: v1.NodeSpec{Taints: []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "win", Labels: map[string]string{"kubernetes.io/os": "windows"}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-a", Labels: linux}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-b", Labels: linux}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "worker-c", Labels: linux}},
+	}
+	controller := true
+	owner := []metav1.OwnerReference{{Kind: "DaemonSet", Name: "agent", Controller: &controller}}
+	pod := func(name string) *v1.Pod {
+		return &v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: name, Labels: map[string]string{"app": "agent"}, OwnerReferences: owner}}
+	}
+	ready := pod("agent-a")
+	ready.Spec.NodeName = "worker-a"
+	ready.Status = v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}}
+	onControlPlane := pod("agent-cp")
+	onControlPlane.Spec.NodeName = "cp"
+	onControlPlane.Status = v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: v1.ConditionTrue}}}
+	pending := pod("agent-b")
+	pending.Spec.Affinity = &v1.Affinity{NodeAffinity: &v1.NodeAffinity{RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+		NodeSelectorTerms: []v1.NodeSelectorTerm{{MatchFields: []v1.NodeSelectorRequirement{{Key: "metadata.name", Operator: v1.NodeSelectorOpIn, Values: []string{"worker-b"}}}}},
+	}}}
+	pending.Status.Phase = v1.PodPending
+	clientset := kubefake.NewSimpleClientset(append(nodes, ready, onControlPlane, pending)...)
+
+	msg := getDaemonSetCoverage(clientset, ds)().(daemonSetCoverageMsg)
+	var got []string
+	for _, r := range msg.rows {
+		got = append(got, fmt.Sprintf("%s|%s|%s", r.node, r.pod, r.status))
+	}
+	want := []string{
+		"worker-c||no pod scheduled",
+		"worker-b|agent-b|Pending",
+		"cp|agent-cp|Running",
+		"worker-a|agent-a|Running",
+		"gpu||taint dedicated=gpu:NoSchedule not tolerated",
+		"win||nodeSelector doesn't match",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("coverage rows:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	m := model{view: viewDetails, viewStack: []viewState{viewDaemonSets}, daemonsets: []appsv1.DaemonSet{ds}, styles: defaultStyles()}
+	updated, _ := m.Update(msg)
+	m = updated.(model)
+	for _, want := range []string{"6 node(s) | 4 eligible | 3 scheduled | 2 ready", "Type:\tRollingUpdate", "4 of 4 updated | 2 available | 2 unavailable (rolling out)"} {
+		if !strings.Contains(m.details, want) {
+			t.Errorf("details missing %q:\n%s", want, m.details)
+		}
+	}
+}
+
+func TestPodQOSClassAndPriority(t *testing.T) {
+	container := func(requests, limits v1.ResourceList) v1.Container {
+		return v1.Container{Resources: v1.ResourceRequirements{Requests: requests, Limits: limits}}
+	}
+	both := v1.ResourceList{v1.ResourceCPU: resource.MustParse("500m"), v1.ResourceMemory: resource.MustParse("256Mi")}
+	for name, tc := range map[string]struct {
+		containers []v1.Container
+		want       v1.PodQOSClass
+	}{
+		"nothing set":                  {[]v1.Container{container(nil, nil)}, v1.PodQOSBestEffort},
+		"limits only":                  {[]v1.Container{container(nil, both)}, v1.PodQOSGuaranteed},
+		"requests equal limits":        {[]v1.Container{container(both, both)}, v1.PodQOSGuaranteed},
+		"requests only":                {[]v1.Container{container(both, nil)}, v1.PodQOSBurstable},
+		"one container without limits": {[]v1.Container{container(both, both), container(nil, nil)}, v1.PodQOSBurstable},
+		"requests below limits": {[]v1.Container{container(
+			v1.ResourceList{v1.ResourceCPU: resource.MustParse("100m"), v1.ResourceMemory: resource.MustParse("256Mi")}, both)}, v1.PodQOSBurstable},
+	} {
+		if got := podQOSClass(v1.Pod{Spec: v1.PodSpec{Containers: tc.containers}}); got != tc.want {
+			t.Errorf("%s: QoS %s, want %s", name, got, tc.want)
+		}
+	}
+	if got := podQOSClass(v1.Pod{Status: v1.PodStatus{QOSClass: v1.PodQOSGuaranteed}}); got != v1.PodQOSGuaranteed {
+		t.Errorf("status QoS class ignored, got %s", got)
+	}
+
+	priority := int32(2000001000)
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "coredns"},
+		Spec:       v1.PodSpec{Priority: &priority, PriorityClassName: "system-node-critical"},
+	}
+	if got := formatPodPriority(pod); got != "2000001000 (system-node-critical)" {
+		t.Errorf("formatPodPriority = %q", got)
+	}
+	m := model{view: viewPods, pods: []v1.Pod{pod}, styles: defaultStyles()}
+	if list := strings.Join(strings.Fields(m.renderPodsList()), " "); !strings.Contains(list, "QOS PRIORITY") || !strings.Contains(list, "BestEffort 2000001000 (system-node-critical)") {
+		t.Errorf("pod list lacks QoS and priority:\n%s", list)
+	}
+}
+
+func TestDashboardShowsVersionAndControlPlaneHealth(t *testing.T) {
+	pod := func(component string, ready v1.ConditionStatus) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: component + "-cp-1", Labels: map[string]string{"tier": "control-plane", "component": component}},
+			Spec:       v1.PodSpec{NodeName: "cp-1"},
+			Status:     v1.PodStatus{Phase: v1.PodRunning, Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: ready}}},
+		}
+	}
+	clientset := kubefake.NewSimpleClientset(pod("etcd", v1.ConditionTrue), pod("kube-scheduler", v1.ConditionFalse))
+	clientset.Discovery().(*discoveryfake.FakeDiscovery).FakedServerVersion = &version.Info{GitVersion: "v1.31.2", Platform: "linux/amd64"}
+
+	m := model{view: viewDashboard, styles: defaultStyles()}
+	updated, _ := m.Update(getClusterHealth(clientset)())
+	m = updated.(model)
+	out := m.renderDashboard()
+	for _, want := range []string{"v1.31.2 (linux/amd64)", "1 of 2 unhealthy (from control-plane pods)", "kube-scheduler", "Running on cp-1"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dashboard missing %q:\n%s", want, out)
+		}
+	}
+
+	clientset = kubefake.NewSimpleClientset(&v1.ComponentStatus{
+		ObjectMeta: metav1.ObjectMeta{Name: "scheduler"},
+		Conditions: []v1.ComponentCondition{{Type: v1.ComponentHealthy, Status: v1.ConditionTrue, Message: "ok"}},
+	})
+	h := getClusterHealth(clientset)().(clusterHealthMsg)
+	if h.source != "ComponentStatuses" || len(h.components) != 1 || !h.components[0].healthy {
+		t.Errorf("fallback to ComponentStatuses: %+v", h)
+	}
+
+	h = getClusterHealth(kubefake.NewSimpleClientset())().(clusterHealthMsg)
+	if !strings.Contains(m.formatControlPlaneHealth(&h), "not visible") {
+		t.Errorf("hidden control plane reported as %q", m.formatControlPlaneHealth(&h))
+	}
+}
+
+func TestServerVersionHonoursTimeout(t *testing.T) {
+	config := &rest.Config{Host: "https://apiserver", Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done() // An API server that never answers
+		return nil, req.Context().Err()
+	})}
+	d, err := discovery.NewDiscoveryClientForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := serverVersion(ctx, d); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("serverVersion() error = %v, want the deadline exceeded", err)
+	}
+}
+
+func TestExcludedNamespacesHiddenFromAllNamespaces(t *testing.T) {
+	pods := []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web"}},
+	}
+	m := model{view: viewPods, excludedNamespaces: []string{"kube-system", "kube-public"}, styles: defaultStyles()}
+	updated, _ := m.Update(podsMsg{pods: pods})
+	m = updated.(model)
+	if len(m.pods) != 1 || m.pods[0].Name != "web" {
+		t.Errorf("pods across all namespaces = %v, want only web", m.pods)
+	}
+	if !strings.Contains(m.headerView(), "all namespaces except kube-system, kube-public") {
+		t.Errorf("header doesn't mention the exclusions: %q", m.headerView())
+	}
+
+	m.events = []v1.Event{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "a"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "b"}},
+	}
+	if got := m.visibleEvents(); len(got) != 1 || got[0].Name != "b" {
+		t.Errorf("visible events = %v, want only b", got)
+	}
+
+	m.selectedNamespace = "kube-system"
+	updated, _ = m.Update(podsMsg{pods: pods[:1], namespace: "kube-system"})
+	m = updated.(model)
+	if len(m.pods) != 1 {
+		t.Errorf("explicitly selected excluded namespace shows %d pods, want 1", len(m.pods))
+	}
+
+	// Replies still in flight when the namespace changed are filtered by the
+	// namespace they were fetched for
+	updated, _ = m.Update(podsMsg{pods: pods})
+	if m = updated.(model); len(m.pods) != 1 || m.pods[0].Name != "web" {
+		t.Errorf("late all-namespaces reply = %v, want only web", m.pods)
+	}
+	m.selectedNamespace = ""
+	updated, _ = m.Update(podsMsg{pods: pods[:1], namespace: "kube-system"})
+	if m = updated.(model); len(m.pods) != 1 {
+		t.Errorf("late kube-system reply shows %d pods, want 1", len(m.pods))
+	}
+}
+
+func TestWideListColumns(t *testing.T) {
+	m := model{view: viewPods, selectedNamespace: "shop", styles: defaultStyles(), pods: []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0", Labels: map[string]string{"tier": "web", "app": "shop"}},
+			Spec: v1.PodSpec{NodeName: "worker-1"}, Status: v1.PodStatus{PodIP: "10.0.0.7"}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "db-0"},
+			Spec: v1.PodSpec{NodeName: "a-much-longer-worker-node-name"}, Status: v1.PodStatus{PodIP: "10.0.0.12"}},
+	}}
+	m.viewport.Height = 10
+	if strings.Contains(m.renderPodsList(), "NODE") {
+		t.Fatal("narrow pod list shows wide columns")
+	}
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	m = updated.(model)
+	lines := strings.Split(m.renderPodsList(), "\n")
+	header, first, second := lines[0], lines[2], lines[3] // The header is underlined
+	for _, want := range []string{"AGE", "IP", "NODE", "LABELS"} {
+		if !strings.Contains(header, want) {
+			t.Errorf("wide header lacks %s: %q", want, header)
+		}
+	}
+	if !strings.Contains(first, "app=shop,tier=web") || !strings.Contains(second, "<none>") {
+		t.Errorf("labels missing from rows:\n%s\n%s", first, second)
+	}
+	// The LABELS column starts after the longest node name in both rows
+	if strings.Index(first, "app=shop") != strings.Index(second, "<none>") || strings.Index(header, "LABELS") != strings.Index(first, "app=shop") {
+		t.Errorf("wide columns not aligned:\n%s\n%s\n%s", header, first, second)
+	}
+
+	m.view = viewEvents
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("w")})
+	if m = updated.(model); !m.wideLists || !m.eventWarningsOnly {
+		t.Errorf("w in Events should keep toggling warnings only, wide %t warnings %t", m.wideLists, m.eventWarningsOnly)
+	}
+}
+
+func TestListColumnsFitLongestName(t *testing.T) {
+	long := "checkout-service-canary-7d9f8b6c5d-x2x9q-with-a-very-long-suffix"
+	m := model{view: viewPods, styles: defaultStyles(), pods: []v1.Pod{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: long}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "payments-production", Name: "db-0"}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+	}}
+	m.viewport.Height = 10
+	lines := strings.Split(m.renderPodsList(), "\n")
+	header, first, second := lines[0], lines[2], lines[3] // The header is underlined
+	if !strings.Contains(first, long) {
+		t.Errorf("long pod name cut short: %q", first)
+	}
+	// STATUS starts after the longest name in every row
+	if strings.Index(header, "STATUS") != strings.Index(first, "Running") || strings.Index(first, "Running") != strings.Index(second, "Running") {
+		t.Errorf("columns not aligned:\n%s\n%s\n%s", header, first, second)
+	}
+	if strings.Index(header, " NAME ") != strings.Index(second, " db-0 ") {
+		t.Errorf("NAME not placed after the longest namespace:\n%s\n%s", header, second)
+	}
+
+	m.viewport.Width = 80
+	first = strings.Split(m.renderPodsList(), "\n")[2]
+	if strings.Contains(first, long) || !strings.Contains(first, long[:29]+"...") {
+		t.Errorf("long pod name should be cut to the terminal's share: %q", first)
+	}
+
+	// Columns naming other objects, like a binding's role, fit their values too
+	longRole := "system:controller:horizontal-pod-autoscaler-custom-metrics"
+	m = model{view: viewRoleBindings, selectedNamespace: "shop", styles: defaultStyles(), roleBindings: []rbacv1.RoleBinding{
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "hpa"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: longRole}},
+		{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "view"}, RoleRef: rbacv1.RoleRef{Kind: "ClusterRole", Name: "view"}},
+	}}
+	m.viewport.Height = 10
+	lines = strings.Split(m.renderRoleBindingsList(), "\n")
+	header, first, second = lines[0], lines[2], lines[3]
+	if !strings.Contains(first, longRole) {
+		t.Errorf("long role name cut short: %q", first)
+	}
+	if i := strings.Index(header, "SUBJECTS"); i != strings.Index(first, "0 ") || i != strings.Index(second, "0 ") {
+		t.Errorf("SUBJECTS not aligned after the longest role:\n%s\n%s\n%s", header, first, second)
+	}
+}
+
+func TestGrepLogsTailsAndFilters(t *testing.T) {
+	for input, wantErr := range map[string]string{
+		"500 timeout|refused": "",
+		"timeout":             "invalid tail count",
+		"500":                 "enter a pattern",
+		"500 (unclosed":       "invalid pattern",
+	} {
+		_, _, err := parseLogGrep(input)
+		if (err == nil) != (wantErr == "") || err != nil && !strings.Contains(err.Error(), wantErr) {
+			t.Errorf("parseLogGrep(%q) = %v, want error %q", input, err, wantErr)
+		}
+	}
+	if tail, re, _ := parseLogGrep("200 fake l.gs"); tail != 200 || re.String() != "fake l.gs" {
+		t.Errorf("parseLogGrep kept tail %d and pattern %q", tail, re)
+	}
+
+	pod := v1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "shop", Name: "web-0"},
+		Spec: v1.PodSpec{Containers: []v1.Container{{Name: "web"}, {Name: "proxy"}}}}
+	client := kubefake.NewSimpleClientset(&pod)
+	msg := getGrepLogs(client, pod, 50, regexp.MustCompile("fake"))().(logsMsg)
+	if msg.logs != "[web] fake logs\n[proxy] fake logs\n" {
+		t.Errorf("matching lines should be kept per container:\n%s", msg.logs)
+	}
+	for _, a := range client.Actions() {
+		if opts := a.(k8stesting.GenericAction).GetValue().(*v1.PodLogOptions); opts.TailLines == nil || *opts.TailLines != 50 {
+			t.Errorf("tail count not pushed down to the API: %+v", opts)
+		}
+	}
+	msg = getGrepLogs(client, pod, 50, regexp.MustCompile("panic"))().(logsMsg)
+	if !strings.Contains(msg.logs, "No line in the last 50") {
+		t.Errorf("no matches: %q", msg.logs)
+	}
+
+	m := model{view: viewDetails, viewStack: []viewState{viewPods}, pods: []v1.Pod{pod}, textInput: textinput.New()}
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("g")})
+	if m = updated.(model); m.view != viewLogGrep || m.textInput.Value() != "1000 " {
+		t.Errorf("g in pod details opened %v with %q", m.view, m.textInput.Value())
+	}
+}
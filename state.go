@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// savedState is what kubeview remembers between runs.
+type savedState struct {
+	View      string   `json:"view"`
+	Namespace string   `json:"namespace"`
+	Favorites []string `json:"favorites,omitempty"`
+}
+
+// stateViews names the views that can be restored on startup. Views that need
+// a selection to make sense (details, logs, custom resources of a CRD) aren't
+// saved; the list they were opened from is saved instead.
+var stateViews = map[viewState]string{
+	viewNodes:           "nodes",
+	viewPods:            "pods",
+	viewPVCs:            "pvcs",
+	viewPVs:             "pvs",
+	viewDeployments:     "deployments",
+	viewStatefulSets:    "statefulsets",
+	viewDaemonSets:      "daemonsets",
+	viewServices:        "services",
+	viewNetworkPolicies: "networkpolicies",
+	viewEvents:          "events",
+	viewRoles:           "roles",
+	viewRoleBindings:    "rolebindings",
+	viewResourceQuotas:  "resourcequotas",
+	viewLimitRanges:     "limitranges",
+	viewPDBs:            "pdbs",
+	viewCronJobs:        "cronjobs",
+	viewEndpointSlices:  "endpointslices",
+	viewCRDs:            "crds",
+	viewDashboard:       "dashboard",
+	viewNodeMap:         "nodemap",
+	viewResourceCounts:  "counts",
+}
+
+// statePath returns the state file location, ~/.config/kubeview/state.json on Linux.
+func statePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "kubeview", "state.json"), nil
+}
+
+// loadState reads the saved state. A missing file yields an empty state.
+func loadState(path string) (savedState, error) {
+	var s savedState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	return s, json.Unmarshal(data, &s)
+}
+
+func saveState(path string, s savedState) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// restoreState applies a saved state to the model. Unknown view names, e.g.
+// from a newer version, are ignored.
+func (m *model) restoreState(s savedState) {
+	m.selectedNamespace = s.Namespace
+	m.favoriteNamespaces = s.Favorites
+	for v, name := range stateViews {
+		if name == s.View {
+			m.view = v
+			return
+		}
+	}
+}
+
+// currentState returns the state to save: the current view if it can be
+// restored, otherwise the most recent one in the navigation history.
+func (m model) currentState() savedState {
+	s := savedState{Namespace: m.selectedNamespace, Favorites: m.favoriteNamespaces}
+	if name, ok := stateViews[m.view]; ok {
+		s.View = name
+		return s
+	}
+	for i := len(m.viewStack) - 1; i >= 0; i-- {
+		if name, ok := stateViews[m.viewStack[i]]; ok {
+			s.View = name
+			return s
+		}
+	}
+	return s
+}
@@ -1,6 +1,10 @@
 package main
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 type Styles struct {
 	Base,
@@ -49,3 +53,63 @@ func defaultStyles() Styles {
 
 	return s
 }
+
+// themes maps the -theme flag values to their Styles presets.
+var themes = map[string]func() Styles{
+	"default":    defaultStyles,
+	"dark":       darkStyles,
+	"light":      lightStyles,
+	"monochrome": monochromeStyles,
+}
+
+// themeNames returns the available theme names in a stable order.
+func themeNames() []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// darkStyles uses brighter colors that stand out on dark backgrounds.
+func darkStyles() Styles {
+	s := defaultStyles()
+	s.HeaderText = s.HeaderText.Foreground(lipgloss.Color("86")) // Aqua
+	s.Header = s.Header.Foreground(lipgloss.Color("86")).BorderForeground(lipgloss.Color("240"))
+	s.SelectedRow = s.SelectedRow.Background(lipgloss.Color("62")).Foreground(lipgloss.Color("230"))
+	s.Success = s.Success.Foreground(lipgloss.Color("42"))
+	s.Warning = s.Warning.Foreground(lipgloss.Color("214"))
+	s.Error = s.Error.Foreground(lipgloss.Color("196"))
+	s.Muted = s.Muted.Foreground(lipgloss.Color("245"))
+	return s
+}
+
+// lightStyles uses darker colors that stay readable on light backgrounds.
+func lightStyles() Styles {
+	s := defaultStyles()
+	s.HeaderText = s.HeaderText.Foreground(lipgloss.Color("25")) // Dark blue
+	s.Header = s.Header.Foreground(lipgloss.Color("25")).BorderForeground(lipgloss.Color("250"))
+	s.SelectedRow = s.SelectedRow.Background(lipgloss.Color("153")).Foreground(lipgloss.Color("16"))
+	s.Success = s.Success.Foreground(lipgloss.Color("28"))
+	s.Warning = s.Warning.Foreground(lipgloss.Color("130"))
+	s.Error = s.Error.Foreground(lipgloss.Color("124"))
+	s.Muted = s.Muted.Foreground(lipgloss.Color("242"))
+	return s
+}
+
+// monochromeStyles uses text attributes only, for terminals without color.
+func monochromeStyles() Styles {
+	s := defaultStyles()
+	s.SelectedRow = s.Row.Copy().
+		Reverse(true)
+	s.Success = lipgloss.NewStyle()
+	s.Warning = lipgloss.NewStyle().
+		Bold(true)
+	s.Error = lipgloss.NewStyle().
+		Bold(true).
+		Underline(true)
+	s.Muted = lipgloss.NewStyle().
+		Faint(true)
+	return s
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// clusterTab is one cluster loaded with -contexts, with its own clients and
+// model state.
+type clusterTab struct {
+	name  string
+	model model
+}
+
+// tabMsg carries a message produced by a tab's command back to that tab, so
+// results for a background cluster never land in the active one.
+type tabMsg struct {
+	tab int
+	msg tea.Msg
+}
+
+// tabs switches between several clusters. Every tab keeps refreshing in the
+// background, so switching shows current data without refetching.
+type tabs struct {
+	tabs   []clusterTab
+	active int
+	styles Styles
+}
+
+// wrapTabCmd tags the messages produced by cmd with the tab they belong to.
+func wrapTabCmd(tab int, cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		switch msg := cmd().(type) {
+		case nil:
+			return nil
+		case tea.QuitMsg:
+			return msg
+		case tea.BatchMsg:
+			cmds := make([]tea.Cmd, len(msg))
+			for i, c := range msg {
+				cmds[i] = wrapTabCmd(tab, c)
+			}
+			return tea.BatchMsg(cmds)
+		default:
+			return tabMsg{tab: tab, msg: msg}
+		}
+	}
+}
+
+func (t tabs) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, len(t.tabs))
+	for i, tab := range t.tabs {
+		cmds[i] = wrapTabCmd(i, tab.model.Init())
+	}
+	return tea.Batch(cmds...)
+}
+
+func (t tabs) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tabMsg:
+		if e, ok := msg.msg.(execProcessMsg); ok {
+			// Started here, since wrapTabCmd would hide tea's own exec message from the program
+			return t, tea.ExecProcess(e.cmd, func(err error) tea.Msg {
+				if done := e.onExit(err); done != nil {
+					return tabMsg{tab: msg.tab, msg: done}
+				}
+				return nil
+			})
+		}
+		return t.updateTab(msg.tab, msg.msg)
+	case tea.WindowSizeMsg:
+		msg.Height -= lipgloss.Height(t.tabBar())
+		cmds := make([]tea.Cmd, len(t.tabs))
+		for i := range t.tabs {
+			var cmd tea.Cmd
+			t, cmd = t.updateTab(i, msg)
+			cmds[i] = cmd
+		}
+		return t, tea.Batch(cmds...)
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "tab":
+			t.active = (t.active + 1) % len(t.tabs)
+			return t, nil
+		case "shift+tab":
+			t.active = (t.active + len(t.tabs) - 1) % len(t.tabs)
+			return t, nil
+		}
+	}
+	return t.updateTab(t.active, msg)
+}
+
+func (t tabs) updateTab(i int, msg tea.Msg) (tabs, tea.Cmd) {
+	updated, cmd := t.tabs[i].model.Update(msg)
+	t.tabs = append([]clusterTab(nil), t.tabs...) // Don't share the backing array with the previous value
+	t.tabs[i].model = updated.(model)
+	return t, wrapTabCmd(i, cmd)
+}
+
+// tabBar renders the cluster names, highlighting the active one.
+func (t tabs) tabBar() string {
+	names := make([]string, len(t.tabs))
+	for i, tab := range t.tabs {
+		style := t.styles.Row
+		if i == t.active {
+			style = t.styles.SelectedRow
+		}
+		names[i] = style.Render(tab.name)
+	}
+	return strings.Join(names, " ") + t.styles.Muted.Render("  (tab) switch cluster")
+}
+
+func (t tabs) View() string {
+	// Background tabs aren't drawn, but their escape sequences (e.g. a
+	// rollout's bell) still go out
+	var seqs string
+	for i, tab := range t.tabs {
+		if i != t.active {
+			seqs += tab.model.terminalSeq
+		}
+	}
+	return seqs + lipgloss.JoinVertical(lipgloss.Left, t.tabBar(), t.tabs[t.active].model.View())
+}